@@ -0,0 +1,328 @@
+package dataset
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/assist-by/mono-buy/futures"
+)
+
+// 다운로드한 파일과 .CHECKSUM의 sha256이 다름
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// data.binance.vision 선물 캔들 아카이브 파일 이름
+// monthly: BTCUSDT-1m-2024-01.zip, daily: BTCUSDT-1m-2024-01-15.zip
+var archiveNamePattern = regexp.MustCompile(`^([A-Z0-9]+)-(\d+[smhdwM])-(\d{4}-\d{2})(?:-(\d{2}))?\.zip$`)
+
+// 2025년부터 일부 아카이브는 시각을 마이크로초로 기록
+const microsecondThreshold = 1e14
+
+type Archive struct {
+	Path     string
+	Symbol   string
+	Interval string
+	// 아카이브가 속한 달 (UTC)
+	Month time.Time
+	// 일별 아카이브면 해당 날짜 (1~31), 월별이면 0
+	Day int
+}
+
+// 한 달치 가져오기 결과
+type ImportResult struct {
+	Symbol   string
+	Interval string
+	Month    time.Time
+	Path     string
+	Candles  int
+	Complete bool
+	// 사용한 아카이브 수와 그중 .CHECKSUM으로 검증한 수
+	Archives int
+	Verified int
+	// 마감된 달 파일이 이미 있어 건너뛰었으면 true
+	Skipped bool
+}
+
+// src 아래의 캔들 아카이브 목록 (하위 디렉터리 포함)
+func FindArchives(src string) ([]Archive, error) {
+	var archives []Archive
+	err := filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		match := archiveNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil
+		}
+
+		month, err := time.Parse("2006-01", match[3])
+		if err != nil {
+			return nil
+		}
+		archive := Archive{
+			Path:     path,
+			Symbol:   match[1],
+			Interval: match[2],
+			Month:    month,
+		}
+		if match[4] != "" {
+			day, err := time.Parse("2006-01-02", match[3]+"-"+match[4])
+			if err != nil {
+				return nil
+			}
+			archive.Day = day.Day()
+		}
+		archives = append(archives, archive)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", src, err)
+	}
+	return archives, nil
+}
+
+// <zip>.CHECKSUM이 있으면 sha256 비교, 없으면 verified=false
+func VerifyChecksum(path string) (verified bool, err error) {
+	content, err := os.ReadFile(path + ".CHECKSUM")
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading checksum: %w", err)
+	}
+
+	// "<sha256>  <파일 이름>" 형식
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return false, fmt.Errorf("empty checksum file: %s.CHECKSUM", path)
+	}
+	expected := strings.ToLower(fields[0])
+
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return false, fmt.Errorf("hashing %s: %w", path, err)
+	}
+	actual := hex.EncodeToString(hash.Sum(nil))
+
+	if actual != expected {
+		return false, fmt.Errorf("%w: %s (expected %s, got %s)", ErrChecksumMismatch, path, expected, actual)
+	}
+	return true, nil
+}
+
+// 아카이브 안의 CSV 캔들 읽기 (헤더가 있는 파일과 없는 파일 모두 지원)
+func ReadArchive(path string) ([]futures.CandleData, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer reader.Close()
+
+	var candles []futures.CandleData
+	for _, file := range reader.File {
+		if !strings.HasSuffix(file.Name, ".csv") {
+			continue
+		}
+		fileCandles, err := readArchiveCSV(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s/%s: %w", path, file.Name, err)
+		}
+		candles = append(candles, fileCandles...)
+	}
+	return candles, nil
+}
+
+func readArchiveCSV(file *zip.File) ([]futures.CandleData, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	reader := csv.NewReader(rc)
+	reader.FieldsPerRecord = -1
+
+	var candles []futures.CandleData
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && len(record) > 0 && record[0] == "open_time" {
+			continue
+		}
+
+		candle, err := futures.ParseKlineRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if candle.OpenTime > microsecondThreshold {
+			candle.OpenTime /= 1000
+			candle.CloseTime /= 1000
+		}
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
+// src의 아카이브를 root 데이터셋으로 가져옴
+// 월별 아카이브가 있으면 그 달은 월별만 쓰고, 없으면 일별 아카이브를 합침
+// 마감된 달 파일이 이미 있으면 건너뜀, accept가 nil이 아니면 통과한 아카이브만 가져옴
+func Import(src, root string, accept func(Archive) bool) ([]ImportResult, error) {
+	archives, err := FindArchives(src)
+	if err != nil {
+		return nil, err
+	}
+
+	type monthKey struct {
+		symbol   string
+		interval string
+		month    time.Time
+	}
+	groups := make(map[monthKey][]Archive)
+	var keys []monthKey
+	for _, archive := range archives {
+		if accept != nil && !accept(archive) {
+			continue
+		}
+		key := monthKey{symbol: archive.Symbol, interval: archive.Interval, month: archive.Month}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], archive)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].symbol != keys[j].symbol {
+			return keys[i].symbol < keys[j].symbol
+		}
+		if keys[i].interval != keys[j].interval {
+			return keys[i].interval < keys[j].interval
+		}
+		return keys[i].month.Before(keys[j].month)
+	})
+
+	var results []ImportResult
+	for _, key := range keys {
+		result, err := importMonth(root, key.symbol, key.interval, key.month, groups[key])
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func importMonth(root, symbol, interval string, month time.Time, archives []Archive) (ImportResult, error) {
+	result := ImportResult{Symbol: symbol, Interval: interval, Month: month}
+
+	complete, err := Complete(root, symbol, interval, month)
+	if err != nil {
+		return result, err
+	}
+	if complete {
+		result.Path = Path(root, symbol, interval, month)
+		result.Complete = true
+		result.Skipped = true
+		return result, nil
+	}
+
+	var monthly *Archive
+	for i := range archives {
+		if archives[i].Day == 0 {
+			monthly = &archives[i]
+			break
+		}
+	}
+	if monthly != nil {
+		archives = []Archive{*monthly}
+	}
+
+	var candles []futures.CandleData
+	for _, archive := range archives {
+		verified, err := VerifyChecksum(archive.Path)
+		if err != nil {
+			return result, err
+		}
+		if verified {
+			result.Verified++
+		}
+		result.Archives++
+		archiveCandles, err := ReadArchive(archive.Path)
+		if err != nil {
+			return result, err
+		}
+		candles = append(candles, archiveCandles...)
+	}
+
+	// 일별 아카이브만 있으면 이전에 받아 둔 진행 중 파일과 합침
+	if monthly == nil {
+		existing, err := ReadFile(PartialPath(root, symbol, interval, month))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return result, err
+		}
+		candles = append(existing, candles...)
+	}
+	candles = futures.NormalizeCandles(candles)
+
+	// 월별 아카이브가 있거나, 이전 실행분과 합쳐 그 달의 모든 날짜가 있으면 마감된 달
+	// 거래소 점검으로 생긴 빈 구간은 완결 여부와 무관 (빈 구간 점검은 CandleValidator에서)
+	result.Complete = monthly != nil || coversMonth(candles, archives, month)
+
+	result.Path, err = WriteMonth(root, symbol, interval, month, candles, result.Complete)
+	if err != nil {
+		return result, err
+	}
+	result.Candles = len(candles)
+	return result, nil
+}
+
+// 마지막 캔들이 달의 끝에 닿았거나, 모든 날짜의 일별 아카이브 또는 캔들이 있는지
+// download 모드와 같은 기준 (점검으로 빠진 캔들이 있어도 마감된 달)
+func coversMonth(candles []futures.CandleData, archives []Archive, month time.Time) bool {
+	if len(candles) == 0 {
+		return false
+	}
+	start := MonthStart(month)
+	end := start.AddDate(0, 1, 0)
+	if candles[len(candles)-1].CloseTime >= end.UnixMilli()-1 {
+		return true
+	}
+
+	days := make(map[int]bool)
+	for _, archive := range archives {
+		days[archive.Day] = true
+	}
+	for _, candle := range candles {
+		days[time.UnixMilli(candle.OpenTime).UTC().Day()] = true
+	}
+	for day := 1; day <= end.AddDate(0, 0, -1).Day(); day++ {
+		if !days[day] {
+			return false
+		}
+	}
+	return true
+}
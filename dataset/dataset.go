@@ -0,0 +1,229 @@
+package dataset
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/assist-by/mono-buy/futures"
+)
+
+// 디스크 데이터셋 구조 (README의 "과거 데이터 다운로드" 참고)
+//
+//	<root>/<SYMBOL>/<interval>/<SYMBOL>-<interval>-<YYYY-MM>.csv.gz          마감된 달 (완료)
+//	<root>/<SYMBOL>/<interval>/<SYMBOL>-<interval>-<YYYY-MM>.partial.csv.gz  진행 중인 달
+//
+// 각 파일은 헤더 한 줄 + 오래된 순 캔들, 시각은 UTC 기준 ms
+// 파일은 임시 파일에 쓴 뒤 rename하므로 존재하면 내용이 온전함
+
+var Header = []string{
+	"open_time",
+	"open",
+	"high",
+	"low",
+	"close",
+	"volume",
+	"close_time",
+	"quote_volume",
+	"trades",
+	"taker_buy_base_volume",
+	"taker_buy_quote_volume",
+}
+
+// 달의 시작 시각 (UTC)
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// start가 속한 달부터 end가 속한 달까지
+func Months(start, end time.Time) []time.Time {
+	var months []time.Time
+	for month := MonthStart(start); !month.After(end); month = month.AddDate(0, 1, 0) {
+		months = append(months, month)
+	}
+	return months
+}
+
+func Dir(root, symbol, interval string) string {
+	return filepath.Join(root, symbol, interval)
+}
+
+// 마감된 달 파일 경로
+func Path(root, symbol, interval string, month time.Time) string {
+	return filepath.Join(Dir(root, symbol, interval), fmt.Sprintf("%s-%s-%s.csv.gz", symbol, interval, MonthStart(month).Format("2006-01")))
+}
+
+// 진행 중인 달 파일 경로
+func PartialPath(root, symbol, interval string, month time.Time) string {
+	return filepath.Join(Dir(root, symbol, interval), fmt.Sprintf("%s-%s-%s.partial.csv.gz", symbol, interval, MonthStart(month).Format("2006-01")))
+}
+
+// 마감된 달 파일이 이미 있는지
+func Complete(root, symbol, interval string, month time.Time) (bool, error) {
+	_, err := os.Stat(Path(root, symbol, interval, month))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+// 한 달치 캔들 저장
+// complete이면 마감된 달 파일로 쓰고 진행 중 파일은 삭제, 아니면 진행 중 파일로 씀
+func WriteMonth(root, symbol, interval string, month time.Time, candles []futures.CandleData, complete bool) (string, error) {
+	path := PartialPath(root, symbol, interval, month)
+	if complete {
+		path = Path(root, symbol, interval, month)
+	}
+
+	if err := WriteFile(path, candles); err != nil {
+		return "", err
+	}
+
+	if complete {
+		partial := PartialPath(root, symbol, interval, month)
+		if err := os.Remove(partial); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return path, fmt.Errorf("removing %s: %w", partial, err)
+		}
+	}
+	return path, nil
+}
+
+// gzip CSV로 저장 (임시 파일에 쓴 뒤 rename)
+func WriteFile(path string, candles []futures.CandleData) (err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	gz := gzip.NewWriter(tmp)
+	if err := writeCSV(gz, candles); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("renaming to %s: %w", path, err)
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, candles []futures.CandleData) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(Header); err != nil {
+		return err
+	}
+
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	for _, candle := range candles {
+		record := []string{
+			strconv.FormatInt(candle.OpenTime, 10),
+			formatFloat(candle.Open),
+			formatFloat(candle.High),
+			formatFloat(candle.Low),
+			formatFloat(candle.Close),
+			formatFloat(candle.Volume),
+			strconv.FormatInt(candle.CloseTime, 10),
+			formatFloat(candle.QuoteAssetVolume),
+			strconv.Itoa(candle.NumberOfTrades),
+			formatFloat(candle.TakerBuyBaseAssetVolume),
+			formatFloat(candle.TakerBuyQuoteAssetVolume),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// gzip CSV 파일 읽기
+func ReadFile(path string) ([]futures.CandleData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	defer gz.Close()
+
+	reader := csv.NewReader(gz)
+	reader.FieldsPerRecord = len(Header)
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("reading %s: missing header", path)
+	}
+
+	candles := make([]futures.CandleData, 0, len(records)-1)
+	for i, record := range records[1:] {
+		candle, err := futures.ParseKlineRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s line %d: %w", path, i+2, err)
+		}
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
+// 기간 내 캔들 읽기 (마감된 달 파일이 없으면 진행 중 파일 사용)
+func Load(root, symbol, interval string, start, end time.Time) ([]futures.CandleData, error) {
+	startTime := start.UnixMilli()
+	endTime := end.UnixMilli()
+
+	var candles []futures.CandleData
+	for _, month := range Months(start, end) {
+		path := Path(root, symbol, interval, month)
+		monthCandles, err := ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			monthCandles, err = ReadFile(PartialPath(root, symbol, interval, month))
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, candle := range monthCandles {
+			if candle.OpenTime >= startTime && candle.OpenTime <= endTime {
+				candles = append(candles, candle)
+			}
+		}
+	}
+	return candles, nil
+}
@@ -6,8 +6,9 @@ import (
 )
 
 type Client struct {
-	webhookURL string
-	httpClient *http.Client
+	webhookURL  string
+	httpClient  *http.Client
+	environment string
 }
 
 // NewClient creates a new Discord webhook client
@@ -17,3 +18,9 @@ func NewClient(webhookURL string) *Client {
 		httpClient: &http.Client{Timeout: 10 * time.Second},
 	}
 }
+
+// SetEnvironment sets the trading environment label shown in every embed footer
+func (c *Client) SetEnvironment(label string) *Client {
+	c.environment = label
+	return c
+}
@@ -9,8 +9,18 @@ import (
 
 // Send sends an embed through the Discord webhook
 func (c *Client) Send(embed *Embed) error {
+	sent := *embed
+	if c.environment != "" {
+		// 테스트넷 알림을 실거래 알림으로 착각하지 않도록 환경 표시
+		footer := EmbedFooter{Text: fmt.Sprintf("[%s]", c.environment)}
+		if embed.Footer != nil {
+			footer.Text = fmt.Sprintf("%s | [%s]", embed.Footer.Text, c.environment)
+		}
+		sent.Footer = &footer
+	}
+
 	message := Message{
-		Embeds: []Embed{*embed},
+		Embeds: []Embed{sent},
 	}
 
 	jsonPayload, err := json.Marshal(message)
@@ -85,3 +85,16 @@ func (c *Client) SendTradeNotification(signalResult lib.SignalResult, orderSize
 	// Discord로 전송
 	return c.Send(&embed)
 }
+
+// SendAccountAlert sends an account event (TP/SL fill, liquidation, margin call) to Discord
+func (c *Client) SendAccountAlert(title, description string, color int) error {
+	embed := Embed{
+		Title:       title,
+		Description: description,
+		Color:       color,
+		Footer:      &EmbedFooter{Text: "🤖 Assist Trading Bot"},
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+
+	return c.Send(&embed)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/assist-by/mono-buy/dataset"
+	"github.com/assist-by/mono-buy/futures"
+)
+
+const dateLayout = "2006-01-02"
+
+// download 모드: 과거 캔들을 받아 데이터셋으로 저장
+// 예) mono-buy download -symbols BTCUSDT,ETHUSDT -interval 15m -start 2024-01-01 -end 2024-06-30
+func runDownload(args []string) error {
+	flags := flag.NewFlagSet("download", flag.ContinueOnError)
+	symbolsFlag := flags.String("symbols", "", "쉼표로 구분한 심볼 (예: BTCUSDT,ETHUSDT)")
+	interval := flags.String("interval", "15m", "캔들 간격 (1m, 5m, 15m, 1h, 4h, 1d ...)")
+	startFlag := flags.String("start", "", "시작 날짜 (YYYY-MM-DD, UTC)")
+	endFlag := flags.String("end", "", "종료 날짜 (YYYY-MM-DD, UTC, 기본값: 오늘)")
+	dir := flags.String("dir", "data", "데이터셋 루트 디렉터리")
+	env := flags.String("env", "mainnet", "접속 환경 (mainnet, testnet)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	symbols := parseSymbols(*symbolsFlag)
+	if len(symbols) == 0 {
+		return fmt.Errorf("-symbols is required")
+	}
+	if _, err := futures.IntervalDuration(*interval); err != nil {
+		return err
+	}
+	start, err := time.Parse(dateLayout, *startFlag)
+	if err != nil {
+		return fmt.Errorf("invalid -start: %w", err)
+	}
+	end := time.Now().UTC()
+	if *endFlag != "" {
+		if end, err = time.Parse(dateLayout, *endFlag); err != nil {
+			return fmt.Errorf("invalid -end: %w", err)
+		}
+	}
+	if end.Before(start) {
+		return fmt.Errorf("-end %s is before -start %s", end.Format(dateLayout), start.Format(dateLayout))
+	}
+
+	environment, err := futures.EnvironmentByName(*env, "", "")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client := futures.NewClient("", "", futures.WithEnvironment(environment))
+	if err := client.SyncServerTimeContext(ctx); err != nil {
+		return fmt.Errorf("syncing server time: %w", err)
+	}
+
+	for _, symbol := range symbols {
+		for _, month := range dataset.Months(start, end) {
+			if err := downloadMonth(ctx, client, *dir, symbol, *interval, month); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("✅ Download finished: %s", *dir)
+	return nil
+}
+
+// 한 달치 캔들을 받아 저장, 이미 마감된 달 파일이 있으면 건너뜀
+func downloadMonth(ctx context.Context, client *futures.FutureClient, root, symbol, interval string, month time.Time) error {
+	complete, err := dataset.Complete(root, symbol, interval, month)
+	if err != nil {
+		return err
+	}
+	if complete {
+		log.Printf("⏭️ %s %s %s already downloaded", symbol, interval, month.Format("2006-01"))
+		return nil
+	}
+
+	now := client.GetTimestamp()
+	monthEnd := month.AddDate(0, 1, 0)
+	rangeEnd := monthEnd.Add(-time.Millisecond)
+	if rangeEnd.UnixMilli() >= now {
+		rangeEnd = time.UnixMilli(now - 1)
+	}
+	if month.UnixMilli() > rangeEnd.UnixMilli() {
+		return nil
+	}
+
+	candles, err := client.GetKlineRangeContext(ctx, symbol, interval, month, rangeEnd)
+	if err != nil {
+		return fmt.Errorf("downloading %s %s %s: %w", symbol, interval, month.Format("2006-01"), err)
+	}
+
+	// 진행 중인 캔들은 저장하지 않음
+	closed := futures.ClosedCandles(candles, now)
+
+	path, err := dataset.WriteMonth(root, symbol, interval, month, closed, monthEnd.UnixMilli() <= now)
+	if err != nil {
+		return err
+	}
+	log.Printf("💾 %s %s %s: %d candles -> %s", symbol, interval, month.Format("2006-01"), len(closed), path)
+	return nil
+}
+
+func parseSymbols(value string) []string {
+	var symbols []string
+	for _, symbol := range strings.Split(value, ",") {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol != "" {
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
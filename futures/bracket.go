@@ -0,0 +1,242 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	bracketLegRetries    = 3
+	bracketLegRetryDelay = 500 * time.Millisecond
+)
+
+// 진입 주문과 TP/SL 보호 주문의 처리 결과
+type BracketResult struct {
+	Entry      *OrderResponse
+	TakeProfit *OrderResponse
+	StopLoss   *OrderResponse
+	// 보호 주문 실패로 포지션을 정리한 주문
+	Flatten *OrderResponse
+
+	// TP/SL이 모두 걸려 있으면 true
+	Protected bool
+	// 보호 주문 실패로 포지션을 정리했으면 true
+	RolledBack bool
+	// 보호 주문 실패 원인
+	LegErrors []error
+}
+
+// 진입 주문 체결 후 TAKE_PROFIT_MARKET / STOP_MARKET 주문을 batchOrders로 함께 등록
+// 보호 주문이 끝내 실패하면 이미 걸린 보호 주문을 취소하고 포지션을 시장가로 정리
+// 재전송 시 중복 주문을 막기 위해 ClientOrderID가 필요 (보호/정리 주문 id는 여기서 파생)
+func (f *FutureClient) PlaceBracketOrder(order OrderRequest) (*BracketResult, error) {
+	return f.PlaceBracketOrderContext(context.Background(), order)
+}
+
+func (f *FutureClient) PlaceBracketOrderContext(ctx context.Context, order OrderRequest) (*BracketResult, error) {
+	if order.ClientOrderID == "" {
+		return nil, fmt.Errorf("bracket order for %s requires a client order id", order.Symbol)
+	}
+
+	entry := order
+	entry.TakeProfit = decimal.Zero
+	entry.StopLoss = decimal.Zero
+
+	// 전송 전에 취소됐으면 진입하지 않음
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("placing entry order: %w", err)
+	}
+
+	// 진입 요청이 거래소에 도달하면 체결됐을 수 있으므로, 전송 이후에는 ctx가 취소돼도
+	// 진입 결과 확인(clientOrderId 조회)과 보호 주문/정리를 끝까지 진행
+	ctx = context.WithoutCancel(ctx)
+
+	entryResp, err := f.PlaceOrderContext(ctx, entry)
+	if err != nil {
+		return nil, fmt.Errorf("placing entry order: %w", err)
+	}
+
+	result := &BracketResult{Entry: entryResp}
+
+	quantity := entryResp.ExecutedQty
+	if !quantity.IsPositive() {
+		// 체결 수량이 없으면 보호할 포지션도 없음
+		return result, fmt.Errorf("entry order not filled: status %s", entryResp.Status)
+	}
+
+	legs := order.protectiveLegs(quantity)
+	if len(legs) == 0 {
+		return result, nil
+	}
+
+	responses, legErrs := f.placeBatchOrders(ctx, legs)
+	for i := range legs {
+		for attempt := 0; legErrs[i] != nil && attempt < bracketLegRetries; attempt++ {
+			time.Sleep(bracketLegRetryDelay)
+			responses[i], legErrs[i] = f.PlaceOrderContext(ctx, legs[i])
+		}
+	}
+
+	failed := false
+	for i, leg := range legs {
+		if legErrs[i] != nil {
+			failed = true
+			result.LegErrors = append(result.LegErrors, fmt.Errorf("%s: %w", leg.Type, legErrs[i]))
+			continue
+		}
+		switch leg.Type {
+		case "TAKE_PROFIT_MARKET":
+			result.TakeProfit = responses[i]
+		case "STOP_MARKET":
+			result.StopLoss = responses[i]
+		}
+	}
+
+	if !failed {
+		result.Protected = true
+		return result, nil
+	}
+
+	// 보호 주문 실패: 남은 보호 주문 취소 후 포지션 정리
+	for _, placed := range []*OrderResponse{result.TakeProfit, result.StopLoss} {
+		if placed == nil {
+			continue
+		}
+		if _, err := f.CancelOrderContext(ctx, order.Symbol, placed.OrderID); err != nil {
+			result.LegErrors = append(result.LegErrors, fmt.Errorf("cancelling order %d: %w", placed.OrderID, err))
+		}
+	}
+
+	flatten := OrderRequest{
+		Symbol:        order.Symbol,
+		Side:          getOppositeOrderSide(order.Side),
+		PositionSide:  order.PositionSide,
+		Type:          "MARKET",
+		Quantity:      quantity,
+		ReduceOnly:    true,
+		ClientOrderID: order.childClientOrderID("fl"),
+	}
+
+	var flattenErr error
+	for attempt := 0; attempt <= bracketLegRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(bracketLegRetryDelay)
+		}
+		result.Flatten, flattenErr = f.PlaceOrderContext(ctx, flatten)
+		if flattenErr == nil {
+			break
+		}
+	}
+	if flattenErr != nil {
+		return result, fmt.Errorf("protective orders failed and flattening position failed: %w", flattenErr)
+	}
+
+	result.RolledBack = true
+	return result, fmt.Errorf("protective orders failed, position flattened: %v", result.LegErrors)
+}
+
+// 진입 방향의 반대로 청산하는 TP/SL 주문 생성 (마크 가격 기준으로 발동)
+func (o OrderRequest) protectiveLegs(quantity decimal.Decimal) []OrderRequest {
+	var legs []OrderRequest
+	if o.TakeProfit.IsPositive() {
+		legs = append(legs, OrderRequest{
+			Symbol:        o.Symbol,
+			Side:          getOppositeOrderSide(o.Side),
+			PositionSide:  o.PositionSide,
+			Type:          "TAKE_PROFIT_MARKET",
+			Quantity:      quantity,
+			StopPrice:     o.TakeProfit,
+			ReduceOnly:    true,
+			WorkingType:   "MARK_PRICE",
+			ClientOrderID: o.childClientOrderID("tp"),
+		})
+	}
+	if o.StopLoss.IsPositive() {
+		legs = append(legs, OrderRequest{
+			Symbol:        o.Symbol,
+			Side:          getOppositeOrderSide(o.Side),
+			PositionSide:  o.PositionSide,
+			Type:          "STOP_MARKET",
+			Quantity:      quantity,
+			StopPrice:     o.StopLoss,
+			ReduceOnly:    true,
+			WorkingType:   "MARK_PRICE",
+			ClientOrderID: o.childClientOrderID("sl"),
+		})
+	}
+	return legs
+}
+
+// 진입 주문의 clientOrderId에서 보호/정리 주문 id 파생
+func (o OrderRequest) childClientOrderID(suffix string) string {
+	if o.ClientOrderID == "" {
+		return ""
+	}
+	return o.ClientOrderID + "-" + suffix
+}
+
+// batchOrders로 여러 주문을 한 번에 전송 (최대 5개)
+// 주문별 결과와 에러를 요청 순서대로 반환
+func (f *FutureClient) placeBatchOrders(ctx context.Context, orders []OrderRequest) ([]*OrderResponse, []error) {
+	responses := make([]*OrderResponse, len(orders))
+	errs := make([]error, len(orders))
+
+	batch := make([]map[string]string, len(orders))
+	for i, order := range orders {
+		item := make(map[string]string)
+		for key, values := range order.params() {
+			item[key] = values[0]
+		}
+		batch[i] = item
+	}
+
+	encoded, err := json.Marshal(batch)
+	if err != nil {
+		for i := range errs {
+			errs[i] = fmt.Errorf("encoding batch orders: %w", err)
+		}
+		return responses, errs
+	}
+
+	params := url.Values{}
+	params.Add("batchOrders", string(encoded))
+
+	body, err := f.doSignedRequest(ctx, "POST", "/fapi/v1/batchOrders", params)
+	if err != nil {
+		for i := range errs {
+			errs[i] = fmt.Errorf("batch order failed: %w", err)
+		}
+		return responses, errs
+	}
+
+	var rawResults []json.RawMessage
+	if err := json.Unmarshal(body, &rawResults); err != nil || len(rawResults) != len(orders) {
+		for i := range errs {
+			errs[i] = fmt.Errorf("parsing batch response: %s", string(body))
+		}
+		return responses, errs
+	}
+
+	for i, raw := range rawResults {
+		// 실패한 주문은 {"code": ..., "msg": ...} 형태로 내려옴
+		binanceErr := &BinanceError{}
+		if err := json.Unmarshal(raw, binanceErr); err == nil && binanceErr.Code != 0 {
+			errs[i] = binanceErr
+			continue
+		}
+
+		var resp OrderResponse
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			errs[i] = fmt.Errorf("parsing order response: %w", err)
+			continue
+		}
+		responses[i] = &resp
+	}
+
+	return responses, errs
+}
@@ -0,0 +1,171 @@
+package futures
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// 지표 계산용 OHLCV 배열 (캔들과 같은 순서, 오래된 순)
+type Series struct {
+	OpenTime       []int64
+	CloseTime      []int64
+	Open           []float64
+	High           []float64
+	Low            []float64
+	Close          []float64
+	Volume         []float64
+	TakerBuyVolume []float64
+}
+
+func NewSeries(candles []CandleData) *Series {
+	n := len(candles)
+	series := &Series{
+		OpenTime:       make([]int64, n),
+		CloseTime:      make([]int64, n),
+		Open:           make([]float64, n),
+		High:           make([]float64, n),
+		Low:            make([]float64, n),
+		Close:          make([]float64, n),
+		Volume:         make([]float64, n),
+		TakerBuyVolume: make([]float64, n),
+	}
+	for i, candle := range candles {
+		series.OpenTime[i] = candle.OpenTime
+		series.CloseTime[i] = candle.CloseTime
+		series.Open[i] = candle.Open
+		series.High[i] = candle.High
+		series.Low[i] = candle.Low
+		series.Close[i] = candle.Close
+		series.Volume[i] = candle.Volume
+		series.TakerBuyVolume[i] = candle.TakerBuyBaseAssetVolume
+	}
+	return series
+}
+
+func (s *Series) Len() int {
+	return len(s.Close)
+}
+
+// 서버 시각 now(ms)에 마감됐는지, CloseTime은 다음 캔들 OpenTime-1이므로 now가 CloseTime보다 커야 마감
+func (c CandleData) IsClosed(now int64) bool {
+	return c.CloseTime < now
+}
+
+// now(ms) 기준으로 마감된 캔들만 남긴 복사본 (진행 중인 캔들로 지표가 다시 그려지지 않도록)
+func ClosedCandles(candles []CandleData, now int64) []CandleData {
+	closed := make([]CandleData, 0, len(candles))
+	for _, candle := range candles {
+		if candle.IsClosed(now) {
+			closed = append(closed, candle)
+		}
+	}
+	return closed
+}
+
+// 값은 읽을 수 있어도 캔들로 말이 안 되는 행은 거부 (0 가격이 지표를 왜곡하지 않도록)
+func (c CandleData) validate() error {
+	for _, value := range []float64{c.Open, c.High, c.Low, c.Close} {
+		if value <= 0 {
+			return fmt.Errorf("non-positive price %v", value)
+		}
+	}
+	if c.High < c.Low {
+		return fmt.Errorf("high %v is below low %v", c.High, c.Low)
+	}
+	if c.Volume < 0 || c.QuoteAssetVolume < 0 || c.TakerBuyBaseAssetVolume < 0 || c.TakerBuyQuoteAssetVolume < 0 {
+		return fmt.Errorf("negative volume")
+	}
+	if c.CloseTime <= c.OpenTime {
+		return fmt.Errorf("close time %d is not after open time %d", c.CloseTime, c.OpenTime)
+	}
+	return nil
+}
+
+// /fapi/v1/klines 응답 파싱, 잘못된 행이 있으면 전체를 거부
+func parseKlines(body []byte) ([]CandleData, error) {
+	var rawCandles [][]json.RawMessage
+	if err := json.Unmarshal(body, &rawCandles); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	candles := make([]CandleData, len(rawCandles))
+	for i, raw := range rawCandles {
+		candle, err := parseKlineRow(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parsing kline row %d: %w", i, err)
+		}
+		candles[i] = candle
+	}
+
+	return candles, nil
+}
+
+// [openTime, "open", "high", "low", "close", "volume", closeTime, "quoteVolume", trades, "takerBuyBase", "takerBuyQuote", "ignore"]
+func parseKlineRow(raw []json.RawMessage) (CandleData, error) {
+	record := make([]string, len(raw))
+	for i, field := range raw {
+		// 가격은 문자열, 시각과 체결 수는 숫자
+		if err := json.Unmarshal(field, &record[i]); err != nil {
+			record[i] = string(field)
+		}
+	}
+	return ParseKlineRecord(record)
+}
+
+// 문자열 필드로 된 캔들 행 파싱 (CSV 아카이브 등, REST 응답과 같은 컬럼 순서)
+func ParseKlineRecord(record []string) (CandleData, error) {
+	if len(record) < 11 {
+		return CandleData{}, fmt.Errorf("expected at least 11 fields, got %d", len(record))
+	}
+
+	var candle CandleData
+	var err error
+	if candle.OpenTime, err = strconv.ParseInt(record[0], 10, 64); err != nil {
+		return CandleData{}, fmt.Errorf("open time: %w", err)
+	}
+	if candle.CloseTime, err = strconv.ParseInt(record[6], 10, 64); err != nil {
+		return CandleData{}, fmt.Errorf("close time: %w", err)
+	}
+	if candle.NumberOfTrades, err = strconv.Atoi(record[8]); err != nil {
+		return CandleData{}, fmt.Errorf("number of trades: %w", err)
+	}
+
+	fields := []struct {
+		name  string
+		index int
+		dest  *float64
+	}{
+		{"open", 1, &candle.Open},
+		{"high", 2, &candle.High},
+		{"low", 3, &candle.Low},
+		{"close", 4, &candle.Close},
+		{"volume", 5, &candle.Volume},
+		{"quote asset volume", 7, &candle.QuoteAssetVolume},
+		{"taker buy base volume", 9, &candle.TakerBuyBaseAssetVolume},
+		{"taker buy quote volume", 10, &candle.TakerBuyQuoteAssetVolume},
+	}
+	for _, field := range fields {
+		if *field.dest, err = parseDecimalString(record[field.index]); err != nil {
+			return CandleData{}, fmt.Errorf("%s: %w", field.name, err)
+		}
+	}
+
+	if err := candle.validate(); err != nil {
+		return CandleData{}, fmt.Errorf("open time %d: %w", candle.OpenTime, err)
+	}
+	return candle, nil
+}
+
+// Binance가 문자열로 주는 숫자 파싱 (NaN, Inf 거부)
+func parseDecimalString(value string) (float64, error) {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,225 @@
+package futures
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const defaultCandleStoreCapacity = 1000
+
+var intervalDurations = map[string]time.Duration{
+	"1m":  time.Minute,
+	"3m":  3 * time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"2h":  2 * time.Hour,
+	"4h":  4 * time.Hour,
+	"6h":  6 * time.Hour,
+	"8h":  8 * time.Hour,
+	"12h": 12 * time.Hour,
+	"1d":  24 * time.Hour,
+	"3d":  3 * 24 * time.Hour,
+	"1w":  7 * 24 * time.Hour,
+}
+
+// 캔들 간격 문자열의 길이 (1M은 달마다 길이가 달라 지원하지 않음)
+func IntervalDuration(interval string) (time.Duration, error) {
+	duration, ok := intervalDurations[interval]
+	if !ok {
+		return 0, fmt.Errorf("unsupported interval: %s", interval)
+	}
+	return duration, nil
+}
+
+// 고정 크기 캔들 버퍼, 가득 차면 가장 오래된 캔들을 덮어씀
+type candleRing struct {
+	data  []CandleData
+	start int
+	size  int
+}
+
+func newCandleRing(capacity int) *candleRing {
+	return &candleRing{data: make([]CandleData, capacity)}
+}
+
+func (r *candleRing) push(candle CandleData) {
+	if r.size < len(r.data) {
+		r.data[(r.start+r.size)%len(r.data)] = candle
+		r.size++
+		return
+	}
+	r.data[r.start] = candle
+	r.start = (r.start + 1) % len(r.data)
+}
+
+func (r *candleRing) last() (CandleData, bool) {
+	if r.size == 0 {
+		return CandleData{}, false
+	}
+	return r.data[(r.start+r.size-1)%len(r.data)], true
+}
+
+func (r *candleRing) setLast(candle CandleData) {
+	r.data[(r.start+r.size-1)%len(r.data)] = candle
+}
+
+func (r *candleRing) reset() {
+	r.start = 0
+	r.size = 0
+}
+
+// 오래된 순으로 복사
+func (r *candleRing) candles() []CandleData {
+	candles := make([]CandleData, r.size)
+	for i := range candles {
+		candles[i] = r.data[(r.start+i)%len(r.data)]
+	}
+	return candles
+}
+
+type candleKey struct {
+	symbol   string
+	interval string
+}
+
+// (심볼, 간격)별 마감된 캔들 저장소
+// 처음 한 번만 전체를 받아오고, 이후에는 빠진 캔들만 REST로 받거나 스트림의 마감 캔들을 추가
+type CandleStore struct {
+	Capacity int
+
+	client *FutureClient
+
+	mu    sync.Mutex
+	rings map[candleKey]*candleRing
+}
+
+func (f *FutureClient) NewCandleStore(capacity int) *CandleStore {
+	if capacity <= 0 {
+		capacity = defaultCandleStoreCapacity
+	}
+	return &CandleStore{
+		Capacity: capacity,
+		client:   f,
+		rings:    make(map[candleKey]*candleRing),
+	}
+}
+
+// 서버 시간 기준으로 마감된 캔들까지 채운 뒤 복사본 반환
+func (s *CandleStore) Sync(ctx context.Context, symbol, interval string) ([]CandleData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.client.timeSynced {
+		if err := s.client.SyncServerTimeContext(ctx); err != nil {
+			return nil, fmt.Errorf("syncing server time: %w", err)
+		}
+	}
+
+	ring := s.ring(symbol, interval)
+	if err := s.fill(ctx, ring, symbol, interval, s.client.GetTimestamp()); err != nil {
+		return nil, err
+	}
+	return ring.candles(), nil
+}
+
+// 스트림에서 받은 마감 캔들 추가 후 복사본 반환
+// 마지막 캔들과 사이가 비어 있으면 빠진 구간을 REST로 먼저 채움
+func (s *CandleStore) Add(ctx context.Context, symbol, interval string, candle CandleData) ([]CandleData, error) {
+	step, err := IntervalDuration(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ring := s.ring(symbol, interval)
+	last, ok := ring.last()
+	if !ok || candle.OpenTime > last.OpenTime+step.Milliseconds() {
+		if ok {
+			log.Printf("candle store %s %s: gap between %d and %d, repairing", symbol, interval, last.OpenTime, candle.OpenTime)
+		}
+		// 이 캔들이 열리기 전에 마감된 캔들까지만 채움
+		if err := s.fill(ctx, ring, symbol, interval, candle.OpenTime); err != nil {
+			return nil, err
+		}
+		last, ok = ring.last()
+	}
+
+	switch {
+	case !ok || candle.OpenTime > last.OpenTime:
+		ring.push(candle)
+	case candle.OpenTime == last.OpenTime:
+		ring.setLast(candle)
+	}
+
+	return ring.candles(), nil
+}
+
+// 추적하지 않는 심볼의 캔들 삭제
+func (s *CandleStore) Remove(symbol, interval string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.rings, candleKey{symbol: symbol, interval: interval})
+}
+
+func (s *CandleStore) ring(symbol, interval string) *candleRing {
+	key := candleKey{symbol: symbol, interval: interval}
+	ring, ok := s.rings[key]
+	if !ok {
+		ring = newCandleRing(s.Capacity)
+		s.rings[key] = ring
+	}
+	return ring
+}
+
+// now(ms) 이전에 마감된 캔들까지 채움
+// 비어 있거나 빠진 구간이 버퍼보다 길면 전체를 다시 받음
+func (s *CandleStore) fill(ctx context.Context, ring *candleRing, symbol, interval string, now int64) error {
+	step, err := IntervalDuration(interval)
+	if err != nil {
+		return err
+	}
+	stepMs := step.Milliseconds()
+
+	last, ok := ring.last()
+	if ok && now-last.OpenTime > int64(s.Capacity)*stepMs {
+		ring.reset()
+		ok = false
+	}
+
+	var candles []CandleData
+	if !ok {
+		start := now - int64(s.Capacity)*stepMs
+		candles, err = s.client.GetKlineRangeContext(ctx, symbol, interval, time.UnixMilli(start), time.UnixMilli(now-1))
+		if err != nil {
+			return fmt.Errorf("backfilling %s %s: %w", symbol, interval, err)
+		}
+	} else {
+		next := last.OpenTime + stepMs
+		// 다음 캔들이 아직 마감되지 않았으면 받을 것이 없음
+		if next+stepMs-1 >= now {
+			return nil
+		}
+		candles, err = s.client.GetKlineRangeContext(ctx, symbol, interval, time.UnixMilli(next), time.UnixMilli(now-1))
+		if err != nil {
+			return fmt.Errorf("fetching %s %s from %d: %w", symbol, interval, next, err)
+		}
+	}
+
+	for _, candle := range candles {
+		if !candle.IsClosed(now) {
+			continue
+		}
+		if last, ok := ring.last(); ok && candle.OpenTime <= last.OpenTime {
+			continue
+		}
+		ring.push(candle)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package futures
+
+import "testing"
+
+// 1분봉: [0, 59999], [60000, 119999]
+var boundaryCandles = []CandleData{
+	{OpenTime: 0, CloseTime: 59999},
+	{OpenTime: 60000, CloseTime: 119999},
+}
+
+func TestCandleIsClosed(t *testing.T) {
+	candle := boundaryCandles[1]
+	tests := []struct {
+		name string
+		now  int64
+		want bool
+	}{
+		{"before close", candle.CloseTime - 1, false},
+		{"now == CloseTime", candle.CloseTime, false},
+		{"now == CloseTime+1", candle.CloseTime + 1, true},
+		{"well after close", candle.CloseTime + 60000, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := candle.IsClosed(tt.now); got != tt.want {
+				t.Errorf("IsClosed(%d) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosedCandles(t *testing.T) {
+	tests := []struct {
+		name string
+		now  int64
+		// 남아야 하는 마지막 캔들의 OpenTime, -1이면 없음
+		wantLast int64
+		wantLen  int
+	}{
+		{"first candle forming", 30000, -1, 0},
+		{"first candle at CloseTime", 59999, -1, 0},
+		{"first candle closed", 60000, 0, 1},
+		{"last candle forming", 90000, 0, 1},
+		{"last candle at CloseTime", 119999, 0, 1},
+		{"last candle at CloseTime+1", 120000, 60000, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClosedCandles(boundaryCandles, tt.now)
+			if len(got) != tt.wantLen {
+				t.Fatalf("ClosedCandles(now=%d) returned %d candles, want %d", tt.now, len(got), tt.wantLen)
+			}
+			if tt.wantLen > 0 && got[len(got)-1].OpenTime != tt.wantLast {
+				t.Errorf("last candle OpenTime = %d, want %d", got[len(got)-1].OpenTime, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestClosedCandlesDoesNotModifyInput(t *testing.T) {
+	candles := append([]CandleData(nil), boundaryCandles...)
+	closed := ClosedCandles(candles, 60000)
+	closed[0].Close = 1
+	if candles[0].Close != 0 {
+		t.Error("ClosedCandles shares backing array with input")
+	}
+}
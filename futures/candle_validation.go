@@ -0,0 +1,276 @@
+package futures
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type CandleIssueType string
+
+const (
+	CANDLE_ISSUE_OUT_OF_ORDER CandleIssueType = "out_of_order"
+	CANDLE_ISSUE_DUPLICATE    CandleIssueType = "duplicate"
+	CANDLE_ISSUE_GAP          CandleIssueType = "gap"
+	CANDLE_ISSUE_ZERO_VOLUME  CandleIssueType = "zero_volume"
+	CANDLE_ISSUE_WICK         CandleIssueType = "wick"
+)
+
+// 점검과 보정 순서 (정렬 -> 중복 제거 -> 빈 구간 채우기 -> 값 보정)
+var CandleIssueTypes = []CandleIssueType{
+	CANDLE_ISSUE_OUT_OF_ORDER,
+	CANDLE_ISSUE_DUPLICATE,
+	CANDLE_ISSUE_GAP,
+	CANDLE_ISSUE_ZERO_VOLUME,
+	CANDLE_ISSUE_WICK,
+}
+
+// 문제가 발견됐을 때 처리 방법
+type CandleAction string
+
+const (
+	// 보정한 캔들로 계속 평가
+	CANDLE_ACTION_REPAIR CandleAction = "repair"
+	// 이번 주기에는 심볼 평가를 건너뜀
+	CANDLE_ACTION_SKIP CandleAction = "skip"
+	// 알림만 보내고 캔들은 그대로 평가
+	CANDLE_ACTION_ALERT CandleAction = "alert"
+)
+
+func (a CandleAction) Valid() bool {
+	switch a {
+	case CANDLE_ACTION_REPAIR, CANDLE_ACTION_SKIP, CANDLE_ACTION_ALERT:
+		return true
+	}
+	return false
+}
+
+type CandleIssue struct {
+	Type CandleIssueType
+	// 문제가 된 캔들의 시작 시각 (빈 구간은 빠진 첫 캔들의 시작 시각)
+	OpenTime int64
+	Detail   string
+}
+
+func (i CandleIssue) String() string {
+	return fmt.Sprintf("%s at %s: %s", i.Type, time.UnixMilli(i.OpenTime).UTC().Format("2006-01-02 15:04"), i.Detail)
+}
+
+// 지표 계산 전 캔들 배열 점검
+type CandleValidator struct {
+	Step time.Duration
+	// 꼬리 길이가 캔들 범위(고가-저가) 중앙값의 이 배수보다 길면 이상치, 0이면 점검하지 않음
+	WickFactor float64
+}
+
+func NewCandleValidator(interval string, wickFactor float64) (*CandleValidator, error) {
+	step, err := IntervalDuration(interval)
+	if err != nil {
+		return nil, err
+	}
+	return &CandleValidator{Step: step, WickFactor: wickFactor}, nil
+}
+
+// 발견한 문제를 CandleIssueTypes 순서로 반환
+// 순서, 중복은 받은 그대로 점검하고 나머지는 정렬, 중복 제거한 캔들로 점검
+func (v *CandleValidator) Validate(candles []CandleData) []CandleIssue {
+	var issues []CandleIssue
+	for i := 1; i < len(candles); i++ {
+		prev, curr := candles[i-1], candles[i]
+		if curr.OpenTime < prev.OpenTime {
+			issues = append(issues, CandleIssue{
+				Type:     CANDLE_ISSUE_OUT_OF_ORDER,
+				OpenTime: curr.OpenTime,
+				Detail:   fmt.Sprintf("follows candle opened at %d", prev.OpenTime),
+			})
+		}
+	}
+
+	seen := make(map[int64]bool, len(candles))
+	for _, candle := range candles {
+		if seen[candle.OpenTime] {
+			issues = append(issues, CandleIssue{
+				Type:     CANDLE_ISSUE_DUPLICATE,
+				OpenTime: candle.OpenTime,
+				Detail:   "open time appears more than once",
+			})
+		}
+		seen[candle.OpenTime] = true
+	}
+
+	ordered := NormalizeCandles(candles)
+	stepMs := v.Step.Milliseconds()
+	for i := 1; i < len(ordered); i++ {
+		expected := ordered[i-1].OpenTime + stepMs
+		if ordered[i].OpenTime > expected {
+			issues = append(issues, CandleIssue{
+				Type:     CANDLE_ISSUE_GAP,
+				OpenTime: expected,
+				Detail:   fmt.Sprintf("%d candles missing", (ordered[i].OpenTime-expected)/stepMs),
+			})
+		}
+	}
+
+	for _, candle := range ordered {
+		if candle.Volume == 0 {
+			issues = append(issues, CandleIssue{
+				Type:     CANDLE_ISSUE_ZERO_VOLUME,
+				OpenTime: candle.OpenTime,
+				Detail:   "no volume",
+			})
+		}
+	}
+
+	if limit := v.wickLimit(ordered); limit > 0 {
+		for _, candle := range ordered {
+			upper, lower := wicks(candle)
+			if upper > limit || lower > limit {
+				issues = append(issues, CandleIssue{
+					Type:     CANDLE_ISSUE_WICK,
+					OpenTime: candle.OpenTime,
+					Detail:   fmt.Sprintf("wick %.8g/%.8g exceeds %.8g", upper, lower, limit),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// 한 가지 문제를 보정한 복사본 반환, CandleIssueTypes 순서로 호출해야 함
+func (v *CandleValidator) Repair(candles []CandleData, issueType CandleIssueType) []CandleData {
+	switch issueType {
+	case CANDLE_ISSUE_OUT_OF_ORDER:
+		return sortCandles(candles)
+	case CANDLE_ISSUE_DUPLICATE:
+		return dedupeCandles(candles)
+	case CANDLE_ISSUE_GAP:
+		// 순서 문제를 보정하지 않는 설정이어도 빈 구간은 정렬된 캔들 기준으로 채움
+		return v.fillGaps(sortCandles(candles))
+	case CANDLE_ISSUE_ZERO_VOLUME:
+		return flattenZeroVolume(candles)
+	case CANDLE_ISSUE_WICK:
+		return v.clampWicks(candles)
+	}
+	return candles
+}
+
+// 빈 구간은 직전 종가로 거래 없는 캔들을 채움
+func (v *CandleValidator) fillGaps(candles []CandleData) []CandleData {
+	stepMs := v.Step.Milliseconds()
+	filled := make([]CandleData, 0, len(candles))
+	for i, candle := range candles {
+		if i > 0 {
+			prev := candles[i-1]
+			for openTime := prev.OpenTime + stepMs; openTime < candle.OpenTime; openTime += stepMs {
+				filled = append(filled, CandleData{
+					OpenTime:  openTime,
+					Open:      prev.Close,
+					High:      prev.Close,
+					Low:       prev.Close,
+					Close:     prev.Close,
+					CloseTime: openTime + stepMs - 1,
+				})
+			}
+		}
+		filled = append(filled, candle)
+	}
+	return filled
+}
+
+// 거래가 없었던 캔들의 가격 변동은 믿을 수 없으므로 직전 종가로 고정
+func flattenZeroVolume(candles []CandleData) []CandleData {
+	repaired := make([]CandleData, len(candles))
+	copy(repaired, candles)
+	for i := 1; i < len(repaired); i++ {
+		if repaired[i].Volume != 0 {
+			continue
+		}
+		price := repaired[i-1].Close
+		repaired[i].Open = price
+		repaired[i].High = price
+		repaired[i].Low = price
+		repaired[i].Close = price
+	}
+	return repaired
+}
+
+// 한계를 넘는 꼬리는 한계 길이로 자름
+func (v *CandleValidator) clampWicks(candles []CandleData) []CandleData {
+	repaired := make([]CandleData, len(candles))
+	copy(repaired, candles)
+
+	limit := v.wickLimit(repaired)
+	if limit == 0 {
+		return repaired
+	}
+	for i := range repaired {
+		candle := &repaired[i]
+		bodyHigh := math.Max(candle.Open, candle.Close)
+		bodyLow := math.Min(candle.Open, candle.Close)
+		if candle.High-bodyHigh > limit {
+			candle.High = bodyHigh + limit
+		}
+		if bodyLow-candle.Low > limit && bodyLow-limit > 0 {
+			candle.Low = bodyLow - limit
+		}
+	}
+	return repaired
+}
+
+// 꼬리 길이 한계, 점검하지 않거나 기준 범위를 구할 수 없으면 0
+func (v *CandleValidator) wickLimit(candles []CandleData) float64 {
+	if v.WickFactor <= 0 {
+		return 0
+	}
+
+	ranges := make([]float64, 0, len(candles))
+	for _, candle := range candles {
+		if r := candle.High - candle.Low; r > 0 {
+			ranges = append(ranges, r)
+		}
+	}
+	if len(ranges) == 0 {
+		return 0
+	}
+	sort.Float64s(ranges)
+	return ranges[len(ranges)/2] * v.WickFactor
+}
+
+func wicks(candle CandleData) (upper, lower float64) {
+	upper = candle.High - math.Max(candle.Open, candle.Close)
+	lower = math.Min(candle.Open, candle.Close) - candle.Low
+	return upper, lower
+}
+
+// OpenTime 순으로 정렬하고 같은 시각의 캔들은 나중에 받은 것만 남긴 복사본
+func NormalizeCandles(candles []CandleData) []CandleData {
+	return dedupeCandles(sortCandles(candles))
+}
+
+// OpenTime 순으로 정렬한 복사본
+func sortCandles(candles []CandleData) []CandleData {
+	sorted := make([]CandleData, len(candles))
+	copy(sorted, candles)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OpenTime < sorted[j].OpenTime
+	})
+	return sorted
+}
+
+// 같은 OpenTime은 나중에 받은 캔들만 남긴 복사본 (순서는 유지)
+func dedupeCandles(candles []CandleData) []CandleData {
+	lastIndex := make(map[int64]int, len(candles))
+	for i, candle := range candles {
+		lastIndex[candle.OpenTime] = i
+	}
+
+	deduped := make([]CandleData, 0, len(lastIndex))
+	for i, candle := range candles {
+		if lastIndex[candle.OpenTime] == i {
+			deduped = append(deduped, candle)
+		}
+	}
+	return deduped
+}
@@ -0,0 +1,70 @@
+package futures
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func minuteCandle(minute int64, close float64) CandleData {
+	return CandleData{
+		OpenTime:  minute * 60000,
+		CloseTime: minute*60000 + 59999,
+		Open:      close,
+		High:      close,
+		Low:       close,
+		Close:     close,
+		Volume:    1,
+	}
+}
+
+func openTimes(candles []CandleData) []int64 {
+	times := make([]int64, len(candles))
+	for i, candle := range candles {
+		times[i] = candle.OpenTime / 60000
+	}
+	return times
+}
+
+func TestNormalizeCandles(t *testing.T) {
+	input := []CandleData{
+		minuteCandle(2, 102),
+		minuteCandle(0, 100),
+		minuteCandle(1, 101),
+		minuteCandle(0, 200), // 나중에 받은 캔들이 남아야 함
+	}
+
+	got := NormalizeCandles(input)
+	if want := []int64{0, 1, 2}; !reflect.DeepEqual(openTimes(got), want) {
+		t.Fatalf("open times = %v, want %v", openTimes(got), want)
+	}
+	if got[0].Close != 200 {
+		t.Errorf("duplicate kept Close = %v, want the later 200", got[0].Close)
+	}
+	if input[0].OpenTime != 2*60000 {
+		t.Error("NormalizeCandles modified its input")
+	}
+}
+
+func TestRepairGapSortsFirst(t *testing.T) {
+	validator := &CandleValidator{Step: time.Minute}
+	// 순서 문제는 보정하지 않는 설정(alert/skip)에서 빈 구간만 보정하는 경우
+	input := []CandleData{
+		minuteCandle(3, 103),
+		minuteCandle(0, 100),
+		minuteCandle(1, 101),
+	}
+
+	got := validator.Repair(input, CANDLE_ISSUE_GAP)
+	if want := []int64{0, 1, 2, 3}; !reflect.DeepEqual(openTimes(got), want) {
+		t.Fatalf("open times = %v, want %v", openTimes(got), want)
+	}
+	if filled := got[2]; filled.Close != 101 || filled.Volume != 0 {
+		t.Errorf("filled candle = %+v, want flat at previous close 101", filled)
+	}
+	for _, issue := range validator.Validate(got) {
+		if issue.Type == CANDLE_ISSUE_GAP || issue.Type == CANDLE_ISSUE_OUT_OF_ORDER {
+			t.Errorf("issue left after repair: %s", issue)
+		}
+	}
+}
@@ -1,55 +1,138 @@
 package futures
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type CandleData struct {
-	OpenTime                 int64  `json:"openTime"`
-	Open                     string `json:"open"`
-	High                     string `json:"high"`
-	Low                      string `json:"low"`
-	Close                    string `json:"close"`
-	Volume                   string `json:"volume"`
-	CloseTime                int64  `json:"closeTime"`
-	QuoteAssetVolume         string `json:"quoteAssetVolume"`
-	NumberOfTrades           int    `json:"numberOfTrades"`
-	TakerBuyBaseAssetVolume  string `json:"takerBuyBaseAssetVolume"`
-	TakerBuyQuoteAssetVolume string `json:"takerBuyQuoteAssetVolume"`
+	OpenTime                 int64   `json:"openTime"`
+	Open                     float64 `json:"open"`
+	High                     float64 `json:"high"`
+	Low                      float64 `json:"low"`
+	Close                    float64 `json:"close"`
+	Volume                   float64 `json:"volume"`
+	CloseTime                int64   `json:"closeTime"`
+	QuoteAssetVolume         float64 `json:"quoteAssetVolume"`
+	NumberOfTrades           int     `json:"numberOfTrades"`
+	TakerBuyBaseAssetVolume  float64 `json:"takerBuyBaseAssetVolume"`
+	TakerBuyQuoteAssetVolume float64 `json:"takerBuyQuoteAssetVolume"`
 }
 
 type AccountBalance struct {
-	Asset              string  `json:"asset"`
-	CrossWalletBalance float64 `json:"crossWalletBalance,string"`
-	CrossUnPnl         float64 `json:"crossUnPnl,string"`
-	AvailableBalance   float64 `json:"availableBalance,string"`
-	WalletBalance      float64 `json:"walletBalance,string"`
+	Asset              string          `json:"asset"`
+	CrossWalletBalance decimal.Decimal `json:"crossWalletBalance"`
+	CrossUnPnl         decimal.Decimal `json:"crossUnPnl"`
+	AvailableBalance   decimal.Decimal `json:"availableBalance"`
+	WalletBalance      decimal.Decimal `json:"walletBalance"`
 }
 
 type Balance struct {
-	Free   float64
-	Locked float64
+	Free   decimal.Decimal
+	Locked decimal.Decimal
 }
 
 type FutureClient struct {
 	APIKey           string
 	SecretKey        string
 	BaseURL          string
+	Environment      Environment
 	ServerTimeOffset int64
 	MaxRetries       int
 	RetryDelay       time.Duration
+	// 모든 REST 요청에 사용 (타임아웃, 프록시, 테스트용 RoundTripper 주입)
+	HTTPClient *http.Client
+	// 캐시된 exchangeInfo 유효 시간
+	ExchangeInfoTTL time.Duration
+
+	// SyncServerTime 호출 여부
+	timeSynced bool
+}
+
+type ClientOption func(*FutureClient)
+
+// 사용할 http.Client 지정
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(f *FutureClient) {
+		f.HTTPClient = httpClient
+	}
+}
+
+// 기본 http.Client의 RoundTripper만 교체
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(f *FutureClient) {
+		f.HTTPClient = &http.Client{
+			Timeout:   f.httpClient().Timeout,
+			Transport: transport,
+		}
+	}
+}
+
+// exchangeInfo 캐시 유효 시간 지정
+func WithExchangeInfoTTL(ttl time.Duration) ClientOption {
+	return func(f *FutureClient) {
+		f.ExchangeInfoTTL = ttl
+	}
+}
+
+// 요청당 타임아웃 지정
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(f *FutureClient) {
+		f.HTTPClient = &http.Client{
+			Timeout:   timeout,
+			Transport: f.httpClient().Transport,
+		}
+	}
 }
 
-func NewClient(apiKey, secretKey string) *FutureClient {
-	return &FutureClient{
-		APIKey:     apiKey,
-		SecretKey:  secretKey,
-		BaseURL:    "https://fapi.binance.com",
-		MaxRetries: 5,
-		RetryDelay: 5 * time.Second,
+func NewClient(apiKey, secretKey string, opts ...ClientOption) *FutureClient {
+	client := &FutureClient{
+		APIKey:      apiKey,
+		SecretKey:   secretKey,
+		BaseURL:     Mainnet.RESTBaseURL,
+		Environment: Mainnet,
+		MaxRetries:  5,
+		RetryDelay:  5 * time.Second,
+		HTTPClient:  &http.Client{Timeout: 15 * time.Second},
+
+		ExchangeInfoTTL: defaultExchangeInfoTTL,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+func (f *FutureClient) httpClient() *http.Client {
+	if f.HTTPClient != nil {
+		return f.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// ctx가 취소되면 바로 반환하는 sleep
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
@@ -58,3 +141,113 @@ func (f *FutureClient) sign(params string) string {
 	h.Write([]byte(params))
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// 서명이 필요한 요청을 전송하고 응답 body를 반환
+func (f *FutureClient) doSignedRequest(ctx context.Context, method, endpoint string, params url.Values) ([]byte, error) {
+	return f.doRequest(ctx, method, endpoint, params, true)
+}
+
+// 모든 REST 요청이 거치는 공통 경로
+// - signed 요청은 GetTimestamp()로 서명하고, -1021(timestamp 오차) 응답이면 서버 시간을 다시 맞춘 뒤 재시도
+// - GET 요청은 결과를 알 수 없는 실패(전송 실패, 5xx)나 429 시 MaxRetries까지 RetryDelay 간격을 늘려가며 재시도
+// - 요청 가중치 한도에 가까우면 요청 전에 대기하고, 418(IP 차단) 중에는 요청하지 않음
+func (f *FutureClient) doRequest(ctx context.Context, method, endpoint string, params url.Values, signed bool) ([]byte, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+
+	if signed && !f.timeSynced {
+		if err := f.SyncServerTimeContext(ctx); err != nil {
+			return nil, fmt.Errorf("syncing server time: %w", err)
+		}
+	}
+
+	idempotent := method == http.MethodGet
+	resynced := false
+
+	var lastErr error
+	for attempt := 0; attempt <= f.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, f.RetryDelay*time.Duration(attempt)); err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := f.send(ctx, method, endpoint, params, signed)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+
+		// 서버 시간과 어긋나 거절된 요청은 반영되지 않았으므로 한 번은 재동기화 후 재시도
+		if signed && !resynced && errors.Is(err, ErrTimestamp) {
+			resynced = true
+			if syncErr := f.SyncServerTimeContext(ctx); syncErr != nil {
+				return nil, fmt.Errorf("resyncing server time: %w", syncErr)
+			}
+			attempt--
+			continue
+		}
+
+		// IP 차단 중에는 재시도하지 않음
+		if errors.Is(err, ErrIPBanned) {
+			return nil, err
+		}
+
+		if !idempotent || !(errors.Is(err, ErrUnknownResult) || errors.Is(err, ErrRateLimited)) {
+			return nil, err
+		}
+	}
+
+	return nil, lastErr
+}
+
+func (f *FutureClient) send(ctx context.Context, method, endpoint string, params url.Values, signed bool) ([]byte, error) {
+	// 한도 대기가 recvWindow보다 길 수 있으므로 대기한 뒤에 timestamp를 넣고 서명
+	limiter := f.limiter()
+	if err := limiter.wait(ctx, requestWeight(endpoint, params), isOrderRequest(method, endpoint)); err != nil {
+		return nil, err
+	}
+
+	query := params.Encode()
+	if signed {
+		params.Set("timestamp", strconv.FormatInt(f.GetTimestamp(), 10))
+		params.Set("recvWindow", "10000")
+		query = params.Encode()
+		query += "&signature=" + f.sign(query)
+	}
+
+	reqURL := f.BaseURL + endpoint
+	if query != "" {
+		reqURL += "?" + query
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	if f.APIKey != "" {
+		req.Header.Set("X-MBX-APIKEY", f.APIKey)
+	}
+
+	resp, err := f.httpClient().Do(req)
+	if err != nil {
+		// 호출자가 취소한 경우도 주문이 이미 전송됐을 수 있어 결과 불명으로 처리
+		return nil, fmt.Errorf("sending request: %w: %w", ErrUnknownResult, err)
+	}
+	defer resp.Body.Close()
+
+	limiter.update(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w: %w", ErrUnknownResult, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newBinanceError(method, endpoint, resp.StatusCode, body)
+	}
+
+	return body, nil
+}
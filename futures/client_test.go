@@ -0,0 +1,41 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+type stubTransport struct{}
+
+func (stubTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, http.ErrNotSupported
+}
+
+func TestClientOptionsWithNilHTTPClient(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []ClientOption
+		wantTimeout   time.Duration
+		wantTransport bool
+	}{
+		{"timeout after nil client", []ClientOption{WithHTTPClient(nil), WithTimeout(3 * time.Second)}, 3 * time.Second, false},
+		{"transport after nil client", []ClientOption{WithHTTPClient(nil), WithTransport(stubTransport{})}, 0, true},
+		{"nil client then both", []ClientOption{WithHTTPClient(nil), WithTransport(stubTransport{}), WithTimeout(time.Second)}, time.Second, true},
+		{"defaults keep timeout", []ClientOption{WithTransport(stubTransport{})}, 15 * time.Second, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient("", "", tt.opts...)
+			if client.HTTPClient == nil {
+				t.Fatal("HTTPClient = nil")
+			}
+			if client.HTTPClient.Timeout != tt.wantTimeout {
+				t.Errorf("Timeout = %v, want %v", client.HTTPClient.Timeout, tt.wantTimeout)
+			}
+			if _, ok := client.HTTPClient.Transport.(stubTransport); ok != tt.wantTransport {
+				t.Errorf("Transport = %T, want stub %v", client.HTTPClient.Transport, tt.wantTransport)
+			}
+		})
+	}
+}
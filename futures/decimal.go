@@ -0,0 +1,36 @@
+package futures
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// 주문 가격, 수량은 float 오차 없이 decimal로 계산
+// Binance가 문자열로 주는 값("0.001")도 decimal.Decimal로 그대로 디코딩됨
+
+// 가격을 tickSize의 배수로 반올림 (TP/SL, 지정가)
+func RoundToTick(price, tickSize decimal.Decimal) decimal.Decimal {
+	if !tickSize.IsPositive() {
+		return price
+	}
+	quotient, remainder := price.QuoRem(tickSize, 0)
+	if remainder.Abs().Mul(decimal.NewFromInt(2)).GreaterThanOrEqual(tickSize) {
+		if remainder.IsNegative() {
+			quotient = quotient.Sub(decimal.NewFromInt(1))
+		} else {
+			quotient = quotient.Add(decimal.NewFromInt(1))
+		}
+	}
+	return quotient.Mul(tickSize)
+}
+
+// 수량을 stepSize의 배수로 내림 (잔고를 넘지 않도록)
+func FloorToStep(quantity, stepSize decimal.Decimal) decimal.Decimal {
+	if !stepSize.IsPositive() {
+		return quantity
+	}
+	quotient, remainder := quantity.QuoRem(stepSize, 0)
+	if remainder.IsNegative() {
+		quotient = quotient.Sub(decimal.NewFromInt(1))
+	}
+	return quotient.Mul(stepSize)
+}
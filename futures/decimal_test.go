@@ -0,0 +1,79 @@
+package futures
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRoundToTick(t *testing.T) {
+	tests := []struct {
+		name  string
+		price decimal.Decimal
+		tick  string
+		want  string
+	}{
+		{"decimal 0.1+0.2", decimal.RequireFromString("0.1").Add(decimal.RequireFromString("0.2")), "0.1", "0.3"},
+		{"float 0.1+0.2", decimal.NewFromFloat(0.1 + 0.2), "0.1", "0.3"},
+		{"float 0.1+0.2 fine tick", decimal.NewFromFloat(0.1 + 0.2), "0.00001", "0.3"},
+		{"already on tick", decimal.RequireFromString("43250.1"), "0.1", "43250.1"},
+		{"below half tick", decimal.RequireFromString("43250.14"), "0.1", "43250.1"},
+		{"half tick rounds up", decimal.RequireFromString("43250.15"), "0.1", "43250.2"},
+		{"half tick 0.5", decimal.RequireFromString("0.25"), "0.5", "0.5"},
+		{"above half tick", decimal.RequireFromString("43250.16"), "0.1", "43250.2"},
+		{"negative below half", decimal.RequireFromString("-0.14"), "0.1", "-0.1"},
+		{"negative half tick rounds away from zero", decimal.RequireFromString("-0.15"), "0.1", "-0.2"},
+		{"negative above half", decimal.RequireFromString("-0.16"), "0.1", "-0.2"},
+		{"tick 0.001", decimal.RequireFromString("0.30000000001"), "0.001", "0.3"},
+		{"integer tick", decimal.RequireFromString("12345.6"), "10", "12350"},
+		{"zero tick keeps price", decimal.RequireFromString("1.23456"), "0", "1.23456"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RoundToTick(tt.price, decimal.RequireFromString(tt.tick))
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("RoundToTick(%s, %s) = %s, want %s", tt.price, tt.tick, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFloorToStep(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity decimal.Decimal
+		step     string
+		want     string
+	}{
+		{"decimal 0.1+0.2", decimal.RequireFromString("0.1").Add(decimal.RequireFromString("0.2")), "0.1", "0.3"},
+		{"float 0.1+0.2", decimal.NewFromFloat(0.1 + 0.2), "0.001", "0.3"},
+		{"step 0.001 just above", decimal.RequireFromString("0.30000000001"), "0.001", "0.3"},
+		{"step 0.001 just below", decimal.RequireFromString("0.29999999999"), "0.001", "0.299"},
+		{"half step floors", decimal.RequireFromString("0.0015"), "0.001", "0.001"},
+		{"already on step", decimal.RequireFromString("1.234"), "0.001", "1.234"},
+		{"negative floors toward minus infinity", decimal.RequireFromString("-0.0015"), "0.001", "-0.002"},
+		{"negative on step", decimal.RequireFromString("-0.002"), "0.001", "-0.002"},
+		{"integer step", decimal.RequireFromString("19.99"), "1", "19"},
+		{"zero step keeps quantity", decimal.RequireFromString("0.123456"), "0", "0.123456"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FloorToStep(tt.quantity, decimal.RequireFromString(tt.step))
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("FloorToStep(%s, %s) = %s, want %s", tt.quantity, tt.step, got, tt.want)
+			}
+		})
+	}
+}
+
+// 결과를 그대로 주문 파라미터로 보내도 step보다 세밀한 자릿수가 남지 않아야 함
+func TestQuantizedStringHasNoExtraPrecision(t *testing.T) {
+	quantity := FloorToStep(decimal.NewFromFloat(0.1+0.2), decimal.RequireFromString("0.001"))
+	if got := quantity.String(); got != "0.3" {
+		t.Errorf("String() = %q, want \"0.3\"", got)
+	}
+	price := RoundToTick(decimal.NewFromFloat(43250.149999999994), decimal.RequireFromString("0.1"))
+	if got := price.String(); got != "43250.1" {
+		t.Errorf("String() = %q, want \"43250.1\"", got)
+	}
+}
@@ -0,0 +1,160 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+type PriceLevel struct {
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+}
+
+// 호가 스냅샷 (Bids는 높은 가격순, Asks는 낮은 가격순)
+type Depth struct {
+	Symbol          string
+	LastUpdateID    int64
+	EventTime       int64
+	TransactionTime int64
+	Bids            []PriceLevel
+	Asks            []PriceLevel
+}
+
+// 누적 호가 잔량
+type DepthSummary struct {
+	BidQuantity decimal.Decimal
+	AskQuantity decimal.Decimal
+	BidNotional decimal.Decimal
+	AskNotional decimal.Decimal
+}
+
+// ["price", "quantity"] 형태의 호가를 PriceLevel로 변환
+type rawPriceLevels [][2]string
+
+func (r rawPriceLevels) levels() ([]PriceLevel, error) {
+	levels := make([]PriceLevel, len(r))
+	for i, raw := range r {
+		price, err := decimal.NewFromString(raw[0])
+		if err != nil {
+			return nil, fmt.Errorf("parsing price %q: %w", raw[0], err)
+		}
+		quantity, err := decimal.NewFromString(raw[1])
+		if err != nil {
+			return nil, fmt.Errorf("parsing quantity %q: %w", raw[1], err)
+		}
+		levels[i] = PriceLevel{Price: price, Quantity: quantity}
+	}
+	return levels, nil
+}
+
+// 호가 스냅샷 조회 (limit: 5, 10, 20, 50, 100, 500, 1000)
+func (f *FutureClient) GetDepth(symbol string, limit int) (*Depth, error) {
+	return f.GetDepthContext(context.Background(), symbol, limit)
+}
+
+func (f *FutureClient) GetDepthContext(ctx context.Context, symbol string, limit int) (*Depth, error) {
+	params := url.Values{}
+	params.Add("symbol", symbol)
+	params.Add("limit", strconv.Itoa(limit))
+
+	body, err := f.doRequest(ctx, "GET", "/fapi/v1/depth", params, false)
+	if err != nil {
+		return nil, fmt.Errorf("getting depth: %w", err)
+	}
+
+	var raw struct {
+		LastUpdateID    int64          `json:"lastUpdateId"`
+		EventTime       int64          `json:"E"`
+		TransactionTime int64          `json:"T"`
+		Bids            rawPriceLevels `json:"bids"`
+		Asks            rawPriceLevels `json:"asks"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	depth := &Depth{
+		Symbol:          symbol,
+		LastUpdateID:    raw.LastUpdateID,
+		EventTime:       raw.EventTime,
+		TransactionTime: raw.TransactionTime,
+	}
+	if depth.Bids, err = raw.Bids.levels(); err != nil {
+		return nil, fmt.Errorf("parsing bids: %w", err)
+	}
+	if depth.Asks, err = raw.Asks.levels(); err != nil {
+		return nil, fmt.Errorf("parsing asks: %w", err)
+	}
+
+	return depth, nil
+}
+
+func (d *Depth) BestBid() (PriceLevel, bool) {
+	if len(d.Bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return d.Bids[0], true
+}
+
+func (d *Depth) BestAsk() (PriceLevel, bool) {
+	if len(d.Asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return d.Asks[0], true
+}
+
+// 최우선 매도호가와 매수호가의 중간 가격
+func (d *Depth) MidPrice() (decimal.Decimal, bool) {
+	bid, okBid := d.BestBid()
+	ask, okAsk := d.BestAsk()
+	if !okBid || !okAsk {
+		return decimal.Zero, false
+	}
+	return bid.Price.Add(ask.Price).Div(decimal.NewFromInt(2)), true
+}
+
+// 스프레드 (가격 차이, 중간 가격 대비 %)
+func (d *Depth) Spread() (spread decimal.Decimal, percent decimal.Decimal, ok bool) {
+	mid, ok := d.MidPrice()
+	if !ok || !mid.IsPositive() {
+		return decimal.Zero, decimal.Zero, false
+	}
+	spread = d.Asks[0].Price.Sub(d.Bids[0].Price)
+	return spread, spread.Div(mid).Mul(decimal.NewFromInt(100)), true
+}
+
+// 중간 가격에서 percent% 이내 호가의 누적 잔량
+func (d *Depth) DepthWithin(percent decimal.Decimal) DepthSummary {
+	var summary DepthSummary
+
+	mid, ok := d.MidPrice()
+	if !ok {
+		return summary
+	}
+	ratio := percent.Div(decimal.NewFromInt(100))
+
+	minBid := mid.Mul(decimal.NewFromInt(1).Sub(ratio))
+	for _, level := range d.Bids {
+		if level.Price.LessThan(minBid) {
+			break
+		}
+		summary.BidQuantity = summary.BidQuantity.Add(level.Quantity)
+		summary.BidNotional = summary.BidNotional.Add(level.Price.Mul(level.Quantity))
+	}
+
+	maxAsk := mid.Mul(decimal.NewFromInt(1).Add(ratio))
+	for _, level := range d.Asks {
+		if level.Price.GreaterThan(maxAsk) {
+			break
+		}
+		summary.AskQuantity = summary.AskQuantity.Add(level.Quantity)
+		summary.AskNotional = summary.AskNotional.Add(level.Price.Mul(level.Quantity))
+	}
+
+	return summary
+}
@@ -0,0 +1,72 @@
+package futures
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 접속할 Binance 선물 환경 (REST / WebSocket 주소)
+type Environment struct {
+	Name        string
+	RESTBaseURL string
+	WSBaseURL   string
+	// 실제 자금이 오가지 않는 환경이면 true
+	Testnet bool
+}
+
+var (
+	Mainnet = Environment{
+		Name:        "mainnet",
+		RESTBaseURL: "https://fapi.binance.com",
+		WSBaseURL:   "wss://fstream.binance.com",
+	}
+	Testnet = Environment{
+		Name:        "testnet",
+		RESTBaseURL: "https://testnet.binancefuture.com",
+		WSBaseURL:   "wss://stream.binancefuture.com",
+		Testnet:     true,
+	}
+)
+
+// 설정 값으로 환경 선택
+// name이 비어 있으면 mainnet, "custom"이면 restURL / wsURL을 그대로 사용 (둘 다 필수)
+func EnvironmentByName(name, restURL, wsURL string) (Environment, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", Mainnet.Name:
+		return Mainnet, nil
+	case Testnet.Name:
+		return Testnet, nil
+	case "custom":
+		if restURL == "" {
+			return Environment{}, fmt.Errorf("custom environment requires REST base URL")
+		}
+		if wsURL == "" {
+			return Environment{}, fmt.Errorf("custom environment requires WebSocket base URL")
+		}
+		return Environment{
+			Name:        "custom",
+			RESTBaseURL: strings.TrimRight(restURL, "/"),
+			WSBaseURL:   strings.TrimRight(wsURL, "/"),
+			// 직접 지정한 주소는 실제 거래소일 수도 있어 mainnet처럼 취급
+			Testnet: false,
+		}, nil
+	}
+
+	return Environment{}, fmt.Errorf("unknown environment: %s", name)
+}
+
+// 로그, 알림에 표시할 이름
+func (e Environment) Label() string {
+	if e.Name == "custom" {
+		return fmt.Sprintf("CUSTOM (%s)", e.RESTBaseURL)
+	}
+	return strings.ToUpper(e.Name)
+}
+
+// 접속 환경 지정
+func WithEnvironment(env Environment) ClientOption {
+	return func(f *FutureClient) {
+		f.Environment = env
+		f.BaseURL = env.RESTBaseURL
+	}
+}
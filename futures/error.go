@@ -1,10 +1,126 @@
 package futures
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
 const (
+	ERROR_UNKNOWN_TIMEOUT            = -1007
+	ERROR_TOO_MANY_REQUESTS          = -1003
+	ERROR_FILTER_FAILURE             = -1013
+	ERROR_TOO_MANY_ORDERS            = -1015
+	ERROR_INVALID_TIMESTAMP          = -1021
+	ERROR_BAD_PRECISION              = -1111
+	ERROR_INVALID_LISTEN_KEY         = -1125
+	ERROR_CANCEL_REJECTED            = -2011
+	ERROR_NO_SUCH_ORDER              = -2013
+	ERROR_BALANCE_NOT_SUFFICIENT     = -2018
+	ERROR_MARGIN_NOT_SUFFICIENT      = -2019
+	ERROR_REDUCE_ONLY_REJECT         = -2022
+	ERROR_MAX_POSITION_EXCEEDED      = -2027
+	ERROR_MIN_LEVERAGE_MARGIN        = -2028
+	ERROR_INVALID_QUANTITY           = -4003
+	ERROR_QUANTITY_GREATER_THAN_MAX  = -4005
+	ERROR_INVALID_TICK_SIZE          = -4014
+	ERROR_INVALID_STEP_SIZE          = -4023
 	ERROR_NO_NEED_TO_CHANGE_POSITION = -4059
+	ERROR_DUPLICATED_CLIENT_ORDER_ID = -4116
+	ERROR_REDUCE_ONLY_ORDER_FAILED   = -4118
+	ERROR_MIN_NOTIONAL               = -4164
+)
+
+// 에러 분류 (errors.Is로 판별)
+var (
+	ErrInsufficientMargin = errors.New("insufficient margin")
+	ErrInvalidQuantity    = errors.New("invalid quantity or precision")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrIPBanned           = errors.New("ip banned")
+	ErrTimestamp          = errors.New("timestamp outside of recvWindow")
+	ErrReduceOnlyRejected = errors.New("reduce only order rejected")
+	ErrUnknownOrder       = errors.New("unknown order")
+
+	// 요청이 거래소에 반영됐는지 알 수 없는 경우 (전송 실패, 5xx, 백엔드 타임아웃)
+	ErrUnknownResult = errors.New("request result unknown")
 )
 
+var errorCategories = map[int]error{
+	ERROR_TOO_MANY_REQUESTS:         ErrRateLimited,
+	ERROR_TOO_MANY_ORDERS:           ErrRateLimited,
+	ERROR_INVALID_TIMESTAMP:         ErrTimestamp,
+	ERROR_FILTER_FAILURE:            ErrInvalidQuantity,
+	ERROR_BAD_PRECISION:             ErrInvalidQuantity,
+	ERROR_INVALID_QUANTITY:          ErrInvalidQuantity,
+	ERROR_QUANTITY_GREATER_THAN_MAX: ErrInvalidQuantity,
+	ERROR_INVALID_TICK_SIZE:         ErrInvalidQuantity,
+	ERROR_INVALID_STEP_SIZE:         ErrInvalidQuantity,
+	ERROR_MIN_NOTIONAL:              ErrInvalidQuantity,
+	ERROR_CANCEL_REJECTED:           ErrUnknownOrder,
+	ERROR_NO_SUCH_ORDER:             ErrUnknownOrder,
+	ERROR_BALANCE_NOT_SUFFICIENT:    ErrInsufficientMargin,
+	ERROR_MARGIN_NOT_SUFFICIENT:     ErrInsufficientMargin,
+	ERROR_MAX_POSITION_EXCEEDED:     ErrInsufficientMargin,
+	ERROR_MIN_LEVERAGE_MARGIN:       ErrInsufficientMargin,
+	ERROR_REDUCE_ONLY_REJECT:        ErrReduceOnlyRejected,
+	ERROR_REDUCE_ONLY_ORDER_FAILED:  ErrReduceOnlyRejected,
+	ERROR_UNKNOWN_TIMEOUT:           ErrUnknownResult,
+}
+
+// Binance 에러 응답 ({"code": ..., "msg": ...})
 type BinanceError struct {
 	Code int    `json:"code"`
 	Msg  string `json:"msg"`
+
+	StatusCode int    `json:"-"`
+	Method     string `json:"-"`
+	Endpoint   string `json:"-"`
+}
+
+// 2xx가 아닌 응답을 BinanceError로 변환
+// JSON 에러 응답이 아니면 (HTML 5xx 페이지 등) body를 그대로 Msg에 담음
+func newBinanceError(method, endpoint string, statusCode int, body []byte) *BinanceError {
+	binanceErr := &BinanceError{}
+	if err := json.Unmarshal(body, binanceErr); err != nil || binanceErr.Msg == "" {
+		binanceErr.Msg = string(body)
+	}
+	binanceErr.StatusCode = statusCode
+	binanceErr.Method = method
+	binanceErr.Endpoint = endpoint
+	return binanceErr
+}
+
+func (e *BinanceError) Error() string {
+	if e.Endpoint == "" {
+		return fmt.Sprintf("binance error %d: %s", e.Code, e.Msg)
+	}
+	return fmt.Sprintf("%s %s: binance error %d (http %d): %s", e.Method, e.Endpoint, e.Code, e.StatusCode, e.Msg)
+}
+
+// 에러 코드와 HTTP 상태 코드로 분류한 에러 반환
+func (e *BinanceError) Unwrap() []error {
+	var categories []error
+	switch {
+	case e.StatusCode == http.StatusTeapot:
+		categories = append(categories, ErrIPBanned)
+	case e.StatusCode == http.StatusTooManyRequests:
+		categories = append(categories, ErrRateLimited)
+	case e.StatusCode >= 500:
+		categories = append(categories, ErrUnknownResult)
+	}
+
+	if category, ok := errorCategories[e.Code]; ok {
+		categories = append(categories, category)
+	}
+	return categories
+}
+
+// 에러에 포함된 Binance 에러 코드 (없으면 0)
+func binanceErrorCode(err error) int {
+	var binanceErr *BinanceError
+	if errors.As(err, &binanceErr) {
+		return binanceErr.Code
+	}
+	return 0
 }
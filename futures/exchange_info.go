@@ -0,0 +1,126 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// 필터가 바뀌는 일은 드물어 1시간마다 갱신
+	defaultExchangeInfoTTL = time.Hour
+	// 캐시에 없는 심볼 조회로 강제 갱신하는 최소 간격 (잘못된 심볼 반복 조회 시 전체 목록을 매번 받지 않도록)
+	exchangeInfoMinRefreshInterval = time.Minute
+)
+
+// 전체 심볼 정보 (캐시에서 공유되므로 읽기 전용으로 사용)
+type ExchangeInfo struct {
+	ServerTime int64
+	Symbols    map[string]*SymbolInfo
+	// 이 정보를 받아온 시각
+	FetchedAt time.Time
+}
+
+// 같은 BaseURL을 쓰는 FutureClient끼리 공유하는 exchangeInfo 캐시
+type exchangeInfoCache struct {
+	mu   sync.Mutex
+	info *ExchangeInfo
+}
+
+var (
+	exchangeInfoCachesMu sync.Mutex
+	exchangeInfoCaches   = map[string]*exchangeInfoCache{}
+)
+
+func (f *FutureClient) exchangeInfoCache() *exchangeInfoCache {
+	exchangeInfoCachesMu.Lock()
+	defer exchangeInfoCachesMu.Unlock()
+
+	cache, ok := exchangeInfoCaches[f.BaseURL]
+	if !ok {
+		cache = &exchangeInfoCache{}
+		exchangeInfoCaches[f.BaseURL] = cache
+	}
+	return cache
+}
+
+// 캐시된 exchangeInfo 조회, ExchangeInfoTTL이 지났으면 다시 받아옴
+func (f *FutureClient) GetExchangeInfo() (*ExchangeInfo, error) {
+	return f.GetExchangeInfoContext(context.Background())
+}
+
+func (f *FutureClient) GetExchangeInfoContext(ctx context.Context) (*ExchangeInfo, error) {
+	cache := f.exchangeInfoCache()
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.info != nil && time.Since(cache.info.FetchedAt) < f.ExchangeInfoTTL {
+		return cache.info, nil
+	}
+
+	info, err := f.fetchExchangeInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cache.info = info
+	return info, nil
+}
+
+// TTL과 관계없이 exchangeInfo를 다시 받아 캐시 갱신
+func (f *FutureClient) RefreshExchangeInfo() (*ExchangeInfo, error) {
+	return f.RefreshExchangeInfoContext(context.Background())
+}
+
+func (f *FutureClient) RefreshExchangeInfoContext(ctx context.Context) (*ExchangeInfo, error) {
+	return f.refreshExchangeInfoOlderThan(ctx, 0)
+}
+
+// 캐시가 minAge보다 오래됐을 때만 다시 받아옴
+func (f *FutureClient) refreshExchangeInfoOlderThan(ctx context.Context, minAge time.Duration) (*ExchangeInfo, error) {
+	cache := f.exchangeInfoCache()
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.info != nil && time.Since(cache.info.FetchedAt) < minAge {
+		return cache.info, nil
+	}
+
+	info, err := f.fetchExchangeInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cache.info = info
+	return info, nil
+}
+
+func (f *FutureClient) fetchExchangeInfo(ctx context.Context) (*ExchangeInfo, error) {
+	body, err := f.doRequest(ctx, "GET", "/fapi/v1/exchangeInfo", nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("getting exchange info: %w", err)
+	}
+
+	var result struct {
+		ServerTime int64           `json:"serverTime"`
+		Symbols    []rawSymbolInfo `json:"symbols"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	info := &ExchangeInfo{
+		ServerTime: result.ServerTime,
+		Symbols:    make(map[string]*SymbolInfo, len(result.Symbols)),
+		FetchedAt:  time.Now(),
+	}
+	for _, raw := range result.Symbols {
+		symbolInfo, err := raw.symbolInfo()
+		if err != nil {
+			return nil, fmt.Errorf("parsing exchange info: %w", err)
+		}
+		info.Symbols[symbolInfo.Symbol] = symbolInfo
+	}
+
+	return info, nil
+}
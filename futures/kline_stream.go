@@ -0,0 +1,120 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+type KlineEvent struct {
+	EventTime int64
+	Symbol    string
+	Interval  string
+	Candle    CandleData
+	// 캔들이 마감됐으면 true (x 필드)
+	Closed bool
+}
+
+type rawKlineEvent struct {
+	EventType string `json:"e"`
+	EventTime int64  `json:"E"`
+	Symbol    string `json:"s"`
+	Kline     struct {
+		OpenTime  int64  `json:"t"`
+		CloseTime int64  `json:"T"`
+		Interval  string `json:"i"`
+		// encoding/json은 대소문자를 구분하지 않고 매칭하므로 "L"이 "l"(Low)로 들어가지 않도록 명시
+		FirstTradeID             int64   `json:"f"`
+		LastTradeID              int64   `json:"L"`
+		Open                     float64 `json:"o,string"`
+		Close                    float64 `json:"c,string"`
+		High                     float64 `json:"h,string"`
+		Low                      float64 `json:"l,string"`
+		Volume                   float64 `json:"v,string"`
+		NumberOfTrades           int     `json:"n"`
+		Closed                   bool    `json:"x"`
+		QuoteAssetVolume         float64 `json:"q,string"`
+		TakerBuyBaseAssetVolume  float64 `json:"V,string"`
+		TakerBuyQuoteAssetVolume float64 `json:"Q,string"`
+	} `json:"k"`
+}
+
+// <symbol>@kline_<interval> 스트림 이름
+func KlineStreamName(symbol, interval string) string {
+	return strings.ToLower(symbol) + "@kline_" + interval
+}
+
+// 심볼들의 캔들 스트림 구독
+func (s *Stream) SubscribeKlines(symbols []string, interval string) error {
+	names := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		names[i] = KlineStreamName(symbol, interval)
+	}
+	return s.Subscribe(names...)
+}
+
+// 심볼들의 캔들 스트림 구독 해제
+func (s *Stream) UnsubscribeKlines(symbols []string, interval string) error {
+	names := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		names[i] = KlineStreamName(symbol, interval)
+	}
+	return s.Unsubscribe(names...)
+}
+
+func ParseKlineEvent(data []byte) (KlineEvent, error) {
+	var raw rawKlineEvent
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return KlineEvent{}, fmt.Errorf("parsing kline event: %w", err)
+	}
+	if raw.EventType != "kline" {
+		return KlineEvent{}, fmt.Errorf("unexpected event type: %s", raw.EventType)
+	}
+
+	event := KlineEvent{
+		EventTime: raw.EventTime,
+		Symbol:    raw.Symbol,
+		Interval:  raw.Kline.Interval,
+		Closed:    raw.Kline.Closed,
+		Candle: CandleData{
+			OpenTime:                 raw.Kline.OpenTime,
+			Open:                     raw.Kline.Open,
+			High:                     raw.Kline.High,
+			Low:                      raw.Kline.Low,
+			Close:                    raw.Kline.Close,
+			Volume:                   raw.Kline.Volume,
+			CloseTime:                raw.Kline.CloseTime,
+			QuoteAssetVolume:         raw.Kline.QuoteAssetVolume,
+			NumberOfTrades:           raw.Kline.NumberOfTrades,
+			TakerBuyBaseAssetVolume:  raw.Kline.TakerBuyBaseAssetVolume,
+			TakerBuyQuoteAssetVolume: raw.Kline.TakerBuyQuoteAssetVolume,
+		},
+	}
+	if err := event.Candle.validate(); err != nil {
+		return KlineEvent{}, fmt.Errorf("invalid kline %s %d: %w", raw.Symbol, raw.Kline.OpenTime, err)
+	}
+
+	return event, nil
+}
+
+// 캔들 스트림 이벤트를 events로 전달 (ctx가 취소될 때까지)
+func (s *Stream) RunKlines(ctx context.Context, events chan<- KlineEvent) error {
+	return s.Run(ctx, func(stream string, data json.RawMessage) {
+		if !strings.Contains(stream, "@kline_") {
+			return
+		}
+
+		event, err := ParseKlineEvent(data)
+		if err != nil {
+			log.Printf("stream %s: %v", stream, err)
+			return
+		}
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// 펀딩비 조회는 한 번에 최대 1000개
+const fundingRatePageLimit = 1000
+
+// 마크 가격, 인덱스 가격, 펀딩비 정보
+type PremiumIndex struct {
+	Symbol               string          `json:"symbol"`
+	MarkPrice            decimal.Decimal `json:"markPrice"`
+	IndexPrice           decimal.Decimal `json:"indexPrice"`
+	EstimatedSettlePrice decimal.Decimal `json:"estimatedSettlePrice"`
+	LastFundingRate      decimal.Decimal `json:"lastFundingRate"`
+	InterestRate         decimal.Decimal `json:"interestRate"`
+	NextFundingTime      int64           `json:"nextFundingTime"`
+	Time                 int64           `json:"time"`
+}
+
+type FundingRate struct {
+	Symbol      string          `json:"symbol"`
+	FundingRate decimal.Decimal `json:"fundingRate"`
+	FundingTime int64           `json:"fundingTime"`
+	// 오래된 기록은 markPrice가 ""로 내려오며, 이때는 0
+	MarkPrice decimal.Decimal `json:"markPrice"`
+}
+
+func (r *FundingRate) UnmarshalJSON(data []byte) error {
+	type fundingRate FundingRate
+	var raw struct {
+		fundingRate
+		MarkPrice string `json:"markPrice"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = FundingRate(raw.fundingRate)
+	if raw.MarkPrice == "" {
+		return nil
+	}
+	markPrice, err := decimal.NewFromString(raw.MarkPrice)
+	if err != nil {
+		return fmt.Errorf("parsing markPrice %q: %w", raw.MarkPrice, err)
+	}
+	r.MarkPrice = markPrice
+	return nil
+}
+
+// 심볼의 마크 가격, 인덱스 가격, 직전 펀딩비, 다음 펀딩 시각 조회
+func (f *FutureClient) GetPremiumIndex(symbol string) (*PremiumIndex, error) {
+	return f.GetPremiumIndexContext(context.Background(), symbol)
+}
+
+func (f *FutureClient) GetPremiumIndexContext(ctx context.Context, symbol string) (*PremiumIndex, error) {
+	params := url.Values{}
+	params.Add("symbol", symbol)
+
+	body, err := f.doRequest(ctx, "GET", "/fapi/v1/premiumIndex", params, false)
+	if err != nil {
+		return nil, fmt.Errorf("getting premium index: %w", err)
+	}
+
+	var index PremiumIndex
+	if err := json.Unmarshal(body, &index); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return &index, nil
+}
+
+// 전체 심볼의 마크 가격, 펀딩비 조회
+func (f *FutureClient) GetPremiumIndexes() ([]PremiumIndex, error) {
+	return f.GetPremiumIndexesContext(context.Background())
+}
+
+func (f *FutureClient) GetPremiumIndexesContext(ctx context.Context) ([]PremiumIndex, error) {
+	body, err := f.doRequest(ctx, "GET", "/fapi/v1/premiumIndex", nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("getting premium indexes: %w", err)
+	}
+
+	var indexes []PremiumIndex
+	if err := json.Unmarshal(body, &indexes); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return indexes, nil
+}
+
+// 기간 내 펀딩비 내역 조회 (오래된 순)
+func (f *FutureClient) GetFundingRateHistory(symbol string, start, end time.Time) ([]FundingRate, error) {
+	return f.GetFundingRateHistoryContext(context.Background(), symbol, start, end)
+}
+
+func (f *FutureClient) GetFundingRateHistoryContext(ctx context.Context, symbol string, start, end time.Time) ([]FundingRate, error) {
+	if end.Before(start) {
+		return nil, fmt.Errorf("invalid range: end %s is before start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	endTime := end.UnixMilli()
+	cursor := start.UnixMilli()
+
+	var rates []FundingRate
+	for cursor <= endTime {
+		params := url.Values{}
+		params.Add("symbol", symbol)
+		params.Add("startTime", strconv.FormatInt(cursor, 10))
+		params.Add("endTime", strconv.FormatInt(endTime, 10))
+		params.Add("limit", strconv.Itoa(fundingRatePageLimit))
+
+		body, err := f.doRequest(ctx, "GET", "/fapi/v1/fundingRate", params, false)
+		if err != nil {
+			return nil, fmt.Errorf("getting funding rates from %d: %w", cursor, err)
+		}
+
+		var page []FundingRate
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+
+		for _, rate := range page {
+			if len(rates) > 0 && rate.FundingTime <= rates[len(rates)-1].FundingTime {
+				continue
+			}
+			rates = append(rates, rate)
+		}
+
+		if len(page) < fundingRatePageLimit {
+			break
+		}
+		cursor = page[len(page)-1].FundingTime + 1
+	}
+
+	return rates, nil
+}
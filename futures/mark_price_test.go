@@ -0,0 +1,80 @@
+package futures
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFundingRateDecodesMarkPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		rate      string
+		markPrice string
+	}{
+		{
+			name:      "with mark price",
+			body:      `{"symbol":"BTCUSDT","fundingRate":"0.00010000","fundingTime":1700000000000,"markPrice":"37000.12345678"}`,
+			rate:      "0.0001",
+			markPrice: "37000.12345678",
+		},
+		{
+			// 오래된 기록은 markPrice가 빈 문자열
+			name:      "empty mark price",
+			body:      `{"symbol":"BTCUSDT","fundingRate":"-0.00030000","fundingTime":1570000000000,"markPrice":""}`,
+			rate:      "-0.0003",
+			markPrice: "0",
+		},
+		{
+			name:      "missing mark price",
+			body:      `{"symbol":"BTCUSDT","fundingRate":"0.00010000","fundingTime":1570000000000}`,
+			rate:      "0.0001",
+			markPrice: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rate FundingRate
+			if err := json.Unmarshal([]byte(tt.body), &rate); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if rate.Symbol != "BTCUSDT" || rate.FundingTime == 0 {
+				t.Errorf("rate = %+v", rate)
+			}
+			if !rate.FundingRate.Equal(decimal.RequireFromString(tt.rate)) {
+				t.Errorf("FundingRate = %s, want %s", rate.FundingRate, tt.rate)
+			}
+			if !rate.MarkPrice.Equal(decimal.RequireFromString(tt.markPrice)) {
+				t.Errorf("MarkPrice = %s, want %s", rate.MarkPrice, tt.markPrice)
+			}
+		})
+	}
+}
+
+func TestFundingRatePageWithEmptyMarkPrice(t *testing.T) {
+	body := `[
+		{"symbol":"BTCUSDT","fundingRate":"0.00010000","fundingTime":1569888000000,"markPrice":""},
+		{"symbol":"BTCUSDT","fundingRate":"0.00010000","fundingTime":1569916800000,"markPrice":"8300.5"}
+	]`
+
+	var page []FundingRate
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(page) != 2 {
+		t.Fatalf("len = %d, want 2", len(page))
+	}
+	if !page[0].MarkPrice.IsZero() || !page[1].MarkPrice.Equal(decimal.RequireFromString("8300.5")) {
+		t.Errorf("mark prices = %s, %s", page[0].MarkPrice, page[1].MarkPrice)
+	}
+}
+
+func TestFundingRateRejectsInvalidMarkPrice(t *testing.T) {
+	var rate FundingRate
+	if err := json.Unmarshal([]byte(`{"symbol":"BTCUSDT","markPrice":"abc"}`), &rate); err == nil {
+		t.Error("expected error for invalid markPrice")
+	}
+}
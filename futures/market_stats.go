@@ -0,0 +1,213 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// 통계 집계 주기 (/futures/data 엔드포인트)
+type StatsPeriod string
+
+const (
+	PERIOD_5M  StatsPeriod = "5m"
+	PERIOD_15M StatsPeriod = "15m"
+	PERIOD_30M StatsPeriod = "30m"
+	PERIOD_1H  StatsPeriod = "1h"
+	PERIOD_2H  StatsPeriod = "2h"
+	PERIOD_4H  StatsPeriod = "4h"
+	PERIOD_6H  StatsPeriod = "6h"
+	PERIOD_12H StatsPeriod = "12h"
+	PERIOD_1D  StatsPeriod = "1d"
+)
+
+// 통계 조회는 한 번에 최대 500개
+const statsLimitMax = 500
+
+func (p StatsPeriod) Valid() bool {
+	switch p {
+	case PERIOD_5M, PERIOD_15M, PERIOD_30M, PERIOD_1H, PERIOD_2H, PERIOD_4H, PERIOD_6H, PERIOD_12H, PERIOD_1D:
+		return true
+	}
+	return false
+}
+
+// 현재 미결제약정
+type OpenInterest struct {
+	Symbol       string  `json:"symbol"`
+	OpenInterest float64 `json:"openInterest,string"`
+	Time         int64   `json:"time"`
+}
+
+// 기간별 미결제약정 (수량, USDT 환산 금액)
+type OpenInterestHist struct {
+	Symbol               string  `json:"symbol"`
+	SumOpenInterest      float64 `json:"sumOpenInterest,string"`
+	SumOpenInterestValue float64 `json:"sumOpenInterestValue,string"`
+	Timestamp            int64   `json:"timestamp"`
+}
+
+// 롱/숏 계정 비율 (LongAccount + ShortAccount = 1)
+type LongShortRatio struct {
+	Symbol         string  `json:"symbol"`
+	LongShortRatio float64 `json:"longShortRatio,string"`
+	LongAccount    float64 `json:"longAccount,string"`
+	ShortAccount   float64 `json:"shortAccount,string"`
+	Timestamp      int64   `json:"timestamp"`
+}
+
+// 시그널과 함께 보는 포지션 통계
+type MarketStats struct {
+	Symbol       string
+	Period       StatsPeriod
+	OpenInterest float64
+	// 최근 period 동안의 미결제약정 변화율 (%)
+	OpenInterestChange float64
+	OpenInterestValue  float64
+	TopTraderRatio     LongShortRatio
+	GlobalRatio        LongShortRatio
+}
+
+func (f *FutureClient) GetOpenInterest(symbol string) (*OpenInterest, error) {
+	return f.GetOpenInterestContext(context.Background(), symbol)
+}
+
+func (f *FutureClient) GetOpenInterestContext(ctx context.Context, symbol string) (*OpenInterest, error) {
+	params := url.Values{}
+	params.Add("symbol", symbol)
+
+	body, err := f.doRequest(ctx, "GET", "/fapi/v1/openInterest", params, false)
+	if err != nil {
+		return nil, fmt.Errorf("getting open interest: %w", err)
+	}
+
+	var openInterest OpenInterest
+	if err := json.Unmarshal(body, &openInterest); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return &openInterest, nil
+}
+
+// 기간별 미결제약정 조회 (오래된 순, 최근 30일까지만 제공)
+func (f *FutureClient) GetOpenInterestHistory(symbol string, period StatsPeriod, limit int) ([]OpenInterestHist, error) {
+	return f.GetOpenInterestHistoryContext(context.Background(), symbol, period, limit)
+}
+
+func (f *FutureClient) GetOpenInterestHistoryContext(ctx context.Context, symbol string, period StatsPeriod, limit int) ([]OpenInterestHist, error) {
+	body, err := f.getStats(ctx, "/futures/data/openInterestHist", symbol, period, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting open interest history: %w", err)
+	}
+
+	var history []OpenInterestHist
+	if err := json.Unmarshal(body, &history); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return history, nil
+}
+
+// 상위 트레이더의 롱/숏 계정 비율 (오래된 순)
+func (f *FutureClient) GetTopTraderLongShortRatio(symbol string, period StatsPeriod, limit int) ([]LongShortRatio, error) {
+	return f.GetTopTraderLongShortRatioContext(context.Background(), symbol, period, limit)
+}
+
+func (f *FutureClient) GetTopTraderLongShortRatioContext(ctx context.Context, symbol string, period StatsPeriod, limit int) ([]LongShortRatio, error) {
+	body, err := f.getStats(ctx, "/futures/data/topLongShortAccountRatio", symbol, period, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting top trader long/short ratio: %w", err)
+	}
+
+	var ratios []LongShortRatio
+	if err := json.Unmarshal(body, &ratios); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return ratios, nil
+}
+
+// 전체 계정의 롱/숏 비율 (오래된 순)
+func (f *FutureClient) GetGlobalLongShortRatio(symbol string, period StatsPeriod, limit int) ([]LongShortRatio, error) {
+	return f.GetGlobalLongShortRatioContext(context.Background(), symbol, period, limit)
+}
+
+func (f *FutureClient) GetGlobalLongShortRatioContext(ctx context.Context, symbol string, period StatsPeriod, limit int) ([]LongShortRatio, error) {
+	body, err := f.getStats(ctx, "/futures/data/globalLongShortAccountRatio", symbol, period, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting global long/short ratio: %w", err)
+	}
+
+	var ratios []LongShortRatio
+	if err := json.Unmarshal(body, &ratios); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return ratios, nil
+}
+
+func (f *FutureClient) getStats(ctx context.Context, endpoint, symbol string, period StatsPeriod, limit int) ([]byte, error) {
+	if !period.Valid() {
+		return nil, fmt.Errorf("invalid period: %q", period)
+	}
+	if limit <= 0 || limit > statsLimitMax {
+		return nil, fmt.Errorf("invalid limit: %d (1-%d)", limit, statsLimitMax)
+	}
+
+	params := url.Values{}
+	params.Add("symbol", symbol)
+	params.Add("period", string(period))
+	params.Add("limit", strconv.Itoa(limit))
+
+	return f.doRequest(ctx, "GET", endpoint, params, false)
+}
+
+// 미결제약정과 롱/숏 비율을 한 번에 조회
+func (f *FutureClient) GetMarketStats(symbol string, period StatsPeriod) (*MarketStats, error) {
+	return f.GetMarketStatsContext(context.Background(), symbol, period)
+}
+
+func (f *FutureClient) GetMarketStatsContext(ctx context.Context, symbol string, period StatsPeriod) (*MarketStats, error) {
+	stats := &MarketStats{
+		Symbol: symbol,
+		Period: period,
+	}
+
+	openInterest, err := f.GetOpenInterestContext(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	stats.OpenInterest = openInterest.OpenInterest
+
+	history, err := f.GetOpenInterestHistoryContext(ctx, symbol, period, 2)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) > 0 {
+		last := history[len(history)-1]
+		stats.OpenInterestValue = last.SumOpenInterestValue
+		if first := history[0]; len(history) > 1 && first.SumOpenInterest > 0 {
+			stats.OpenInterestChange = (last.SumOpenInterest - first.SumOpenInterest) / first.SumOpenInterest * 100
+		}
+	}
+
+	topRatios, err := f.GetTopTraderLongShortRatioContext(ctx, symbol, period, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(topRatios) > 0 {
+		stats.TopTraderRatio = topRatios[len(topRatios)-1]
+	}
+
+	globalRatios, err := f.GetGlobalLongShortRatioContext(ctx, symbol, period, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(globalRatios) > 0 {
+		stats.GlobalRatio = globalRatios[len(globalRatios)-1]
+	}
+
+	return stats, nil
+}
@@ -1,12 +1,16 @@
 package futures
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strconv"
+
+	"github.com/shopspring/decimal"
 )
 
 type OrderSide string
@@ -18,6 +22,7 @@ const (
 
 	LONG  PositionSide = "LONG"
 	SHORT PositionSide = "SHORT"
+	BOTH  PositionSide = "BOTH"
 )
 
 type OrderRequest struct {
@@ -25,107 +30,147 @@ type OrderRequest struct {
 	Side         OrderSide
 	PositionSide PositionSide
 	Type         string
-	Quantity     float64
-	Price        float64
-	StopPrice    float64
-	TakeProfit   float64
-	StopLoss     float64
+	Quantity     decimal.Decimal
+	Price        decimal.Decimal
+	StopPrice    decimal.Decimal
+	TakeProfit   decimal.Decimal
+	StopLoss     decimal.Decimal
 	TimeInForce  string
+	ReduceOnly   bool
+	// STOP/TAKE_PROFIT 발동 기준 가격 (MARK_PRICE / CONTRACT_PRICE)
+	WorkingType string
+	// 비어 있지 않으면 newClientOrderId로 전송해 재시도 시 중복 주문을 막음
+	ClientOrderID string
 }
 
-func (f *FutureClient) PlaceOrder(order OrderRequest) error {
-	// 테스트를 위해 주석 처리
-
-	// timestamp := time.Now().UnixMilli()
-	// params := url.Values{}
-	// params.Add("symbol", order.Symbol)
-	// params.Add("side", string(order.Side))
-	// params.Add("positionSide", string(order.PositionSide))
-	// params.Add("type", "MARKET")
-	// params.Add("quantity", strconv.FormatFloat(order.Quantity, 'f', -1, 64))
-	// params.Add("timestamp", strconv.FormatInt(timestamp, 10))
-
-	// signature := f.sign(params.Encode())
-	// params.Add("signature", signature)
-
-	// req, err := http.NewRequest("POST", f.BaseURL+"/fapi/v1/order", nil)
-	// if err != nil {
-	// 	return fmt.Errorf("creating request: %w", err)
-	// }
-
-	// req.Header.Set("X-MBX-APIKEY", f.APIKey)
-	// req.URL.RawQuery = params.Encode()
-
-	// resp, err := http.DefaultClient.Do(req)
-	// if err != nil {
-	// 	return fmt.Errorf("sending request: %w", err)
-	// }
-
-	// defer resp.Body.Close()
-
-	// if resp.StatusCode != http.StatusOK {
-	// 	body, _ := io.ReadAll(resp.Body)
-	// 	return fmt.Errorf("order failed: %s", string(body))
-	// }
-
-	// if order.TakeProfit > 0 {
-	// 	tpParams := url.Values{}
-	// 	tpParams.Add("symbol", order.Symbol)
-	// 	tpParams.Add("side", string(getOppositeOrderSide(order.Side)))
-	// 	tpParams.Add("type", "TAKE_PROFIT_MARKET")
-	// 	tpParams.Add("stopPrice", strconv.FormatFloat(order.TakeProfit, 'f', -1, 64))
-	// 	tpParams.Add("quantity", strconv.FormatFloat(order.Quantity, 'f', -1, 64))
-	// 	tpParams.Add("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
-
-	// 	signature = f.sign(tpParams.Encode())
-	// 	tpParams.Add("signature", signature)
-
-	// 	if err := f.placeStopOrder(tpParams); err != nil {
-	// 		return fmt.Errorf("placing take profit: %w", err)
-	// 	}
-	// }
-
-	// if order.StopLoss > 0 {
-	// 	slParams := url.Values{}
-	// 	slParams.Add("symbol", order.Symbol)
-	// 	slParams.Add("side", string(getOppositeOrderSide(order.Side)))
-	// 	slParams.Add("type", "STOP_MARKET")
-	// 	slParams.Add("stopPrice", strconv.FormatFloat(order.StopLoss, 'f', -1, 64))
-	// 	slParams.Add("quantity", strconv.FormatFloat(order.Quantity, 'f', -1, 64))
-	// 	slParams.Add("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
-
-	// 	signature = f.sign(slParams.Encode())
-	// 	slParams.Add("signature", signature)
-
-	// 	if err := f.placeStopOrder(slParams); err != nil {
-	// 		return fmt.Errorf("placing stop loss: %w", err)
-	// 	}
-	// }
+type OrderResponse struct {
+	OrderID       int64           `json:"orderId"`
+	ClientOrderID string          `json:"clientOrderId"`
+	Symbol        string          `json:"symbol"`
+	Status        string          `json:"status"`
+	Side          OrderSide       `json:"side"`
+	PositionSide  PositionSide    `json:"positionSide"`
+	Type          string          `json:"type"`
+	OrigQty       decimal.Decimal `json:"origQty"`
+	ExecutedQty   decimal.Decimal `json:"executedQty"`
+	AvgPrice      decimal.Decimal `json:"avgPrice"`
+	UpdateTime    int64           `json:"updateTime"`
+}
 
-	return nil
+// 주문 파라미터 생성 (timestamp, signature 제외)
+func (o OrderRequest) params() url.Values {
+	orderType := o.Type
+	if orderType == "" {
+		orderType = "MARKET"
+	}
+
+	params := url.Values{}
+	params.Add("symbol", o.Symbol)
+	params.Add("side", string(o.Side))
+	if o.PositionSide != "" {
+		params.Add("positionSide", string(o.PositionSide))
+	}
+	params.Add("type", orderType)
+	params.Add("quantity", o.Quantity.String())
+	if orderType == "LIMIT" {
+		params.Add("price", o.Price.String())
+		timeInForce := o.TimeInForce
+		if timeInForce == "" {
+			timeInForce = "GTC"
+		}
+		params.Add("timeInForce", timeInForce)
+	}
+	if o.StopPrice.IsPositive() {
+		params.Add("stopPrice", o.StopPrice.String())
+	}
+	if o.WorkingType != "" {
+		params.Add("workingType", o.WorkingType)
+	}
+	if o.ClientOrderID != "" {
+		params.Add("newClientOrderId", o.ClientOrderID)
+	}
+	// Hedge 모드에서는 positionSide로 청산 방향이 정해지므로 reduceOnly를 보낼 수 없음
+	if o.ReduceOnly && (o.PositionSide == "" || o.PositionSide == BOTH) {
+		params.Add("reduceOnly", "true")
+	}
+	return params
 }
 
-func (f *FutureClient) placeStopOrder(params url.Values) error {
-	req, err := http.NewRequest("POST", f.BaseURL+"/fapi/v1/order", nil)
-	if err != nil {
-		return err
+// 같은 시그널(심볼, 시그널 종류, 캔들 종료 시간)이면 항상 같은 clientOrderId 생성
+// Binance clientOrderId는 최대 36자라 해시 앞부분만 사용
+func NewClientOrderID(symbol, signal string, closeTime int64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d", symbol, signal, closeTime)))
+	return "mb-" + hex.EncodeToString(sum[:])[:24]
+}
+
+// 단일 주문 전송 후 실제 체결 결과를 반환 (TP/SL은 PlaceBracketOrder 사용)
+// ClientOrderID가 있으면 결과를 알 수 없는 실패 시 기존 주문을 먼저 조회한 뒤에만 재전송
+func (f *FutureClient) PlaceOrder(order OrderRequest) (*OrderResponse, error) {
+	return f.PlaceOrderContext(context.Background(), order)
+}
+
+func (f *FutureClient) PlaceOrderContext(ctx context.Context, order OrderRequest) (*OrderResponse, error) {
+	if order.ClientOrderID == "" {
+		return f.placeOrder(ctx, order)
 	}
 
-	req.Header.Set("X-MBX-APIKEY", f.APIKey)
-	req.URL.RawQuery = params.Encode()
+	var lastErr error
+	for attempt := 0; attempt <= f.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, f.RetryDelay); err != nil {
+				return nil, fmt.Errorf("order %s status unknown: %w: %w", order.ClientOrderID, err, lastErr)
+			}
+
+			// 이전 요청이 거래소에 도달했는지 확인
+			existing, err := f.QueryOrderByClientIDContext(ctx, order.Symbol, order.ClientOrderID)
+			if err == nil {
+				return existing.response(), nil
+			}
+			if !errors.Is(err, ErrUnknownOrder) {
+				lastErr = err
+				continue
+			}
+		}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return err
+		resp, err := f.placeOrder(ctx, order)
+		if err == nil {
+			return resp, nil
+		}
+
+		// 같은 clientOrderId 주문이 이미 있으면 그 주문을 결과로 사용
+		if binanceErrorCode(err) == ERROR_DUPLICATED_CLIENT_ORDER_ID {
+			existing, queryErr := f.QueryOrderByClientIDContext(ctx, order.Symbol, order.ClientOrderID)
+			if queryErr != nil {
+				return nil, fmt.Errorf("querying duplicated order %s: %w", order.ClientOrderID, queryErr)
+			}
+			return existing.response(), nil
+		}
+
+		if !errors.Is(err, ErrUnknownResult) {
+			return nil, err
+		}
+		lastErr = err
 	}
 
-	defer resp.Body.Close()
+	return nil, fmt.Errorf("order %s status unknown after %d retries: %w", order.ClientOrderID, f.MaxRetries, lastErr)
+}
+
+func (f *FutureClient) placeOrder(ctx context.Context, order OrderRequest) (*OrderResponse, error) {
+	params := order.params()
+	// MARKET 주문의 체결 수량, 평균가를 바로 받기 위해 RESULT 사용
+	params.Add("newOrderRespType", "RESULT")
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("stop order failed: %s", string(body))
+	body, err := f.doSignedRequest(ctx, "POST", "/fapi/v1/order", params)
+	if err != nil {
+		return nil, fmt.Errorf("order failed: %w", err)
 	}
-	return nil
+
+	var result OrderResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return &result, nil
 }
 
 func getOppositeOrderSide(side OrderSide) OrderSide {
@@ -137,39 +182,16 @@ func getOppositeOrderSide(side OrderSide) OrderSide {
 }
 
 func (f *FutureClient) SetLeverage(symbol string, leverage int) error {
-	// 서버 시간 가져오기
-	serverTime, err := f.GetServerTime()
-	if err != nil {
-		return fmt.Errorf("getting server time: %w", err)
-	}
+	return f.SetLeverageContext(context.Background(), symbol, leverage)
+}
 
+func (f *FutureClient) SetLeverageContext(ctx context.Context, symbol string, leverage int) error {
 	params := url.Values{}
 	params.Add("symbol", symbol)
 	params.Add("leverage", strconv.Itoa(leverage))
-	params.Add("timestamp", strconv.FormatInt(serverTime, 10))
-	params.Add("recvWindow", "10000")
-
-	signature := f.sign(params.Encode())
-	params.Add("signature", signature)
-
-	endpoint := "/fapi/v1/leverage"
-
-	req, err := http.NewRequest("POST", f.BaseURL+endpoint+"?"+params.Encode(), nil)
-	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("X-MBX-APIKEY", f.APIKey)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("sending request: %w", err)
-	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("setting leverage failed: %s", string(body))
+	if _, err := f.doSignedRequest(ctx, "POST", "/fapi/v1/leverage", params); err != nil {
+		return fmt.Errorf("setting leverage failed: %w", err)
 	}
 
 	return nil
@@ -178,65 +200,20 @@ func (f *FutureClient) SetLeverage(symbol string, leverage int) error {
 // 포지션 모드 설정 (Hedge Mode / One-way Mode)
 
 func (f *FutureClient) SetPositionMode(hedgeMode bool) error {
-	serverTime, err := f.GetServerTime()
-	if err != nil {
-		return fmt.Errorf("getting server time: %w", err)
-	}
+	return f.SetPositionModeContext(context.Background(), hedgeMode)
+}
 
+func (f *FutureClient) SetPositionModeContext(ctx context.Context, hedgeMode bool) error {
 	params := url.Values{}
 	params.Add("dualSidePosition", strconv.FormatBool(hedgeMode))
-	params.Add("timestamp", strconv.FormatInt(serverTime, 10))
-	params.Add("recvWindow", "10000")
-
-	signature := f.sign(params.Encode())
-	params.Add("signature", signature)
-
-	endpoint := "/fapi/v1/positionSide/dual"
-	req, err := http.NewRequest("POST", f.BaseURL+endpoint+"?"+params.Encode(), nil)
-	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("X-MBX-APIKEY", f.APIKey)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("sending request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-
-		// Binance 에러 응답 파싱
-		var binanceErr BinanceError
-		if err := json.Unmarshal(body, &binanceErr); err != nil {
-			return fmt.Errorf("parsing error response: %w", err)
-		}
 
+	if _, err := f.doSignedRequest(ctx, "POST", "/fapi/v1/positionSide/dual", params); err != nil {
 		// 이미 설정되어 있는 경우
-		if binanceErr.Code == ERROR_NO_NEED_TO_CHANGE_POSITION {
+		if binanceErrorCode(err) == ERROR_NO_NEED_TO_CHANGE_POSITION {
 			return nil
 		}
-
-		return fmt.Errorf("setting position mode failed: %s", string(body))
+		return fmt.Errorf("setting position mode failed: %w", err)
 	}
 
 	return nil
 }
-
-// // 수량을 stepSize에 맞게 내림하는 유틸리티 함수
-// func FloorToStepSize(quantity float64, stepSize float64) float64 {
-// 	precision := getPrecisionFromStepSize(stepSize)
-// 	factor := float64(10 * precision)
-// 	return float64(int(quantity*factor)) / factor
-// }
-
-func getPrecisionFromStepSize(stepSize float64) int {
-	precision := 0
-	for stepSize < 1 {
-		stepSize *= 10
-		precision++
-	}
-	return precision
-}
@@ -0,0 +1,286 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+const defaultOrderBookSnapshotLimit = 1000
+
+// 스냅샷 이후 업데이트가 이어지지 않음 (다시 스냅샷부터 동기화 필요)
+var ErrOrderBookGap = errors.New("order book update gap")
+
+// @depth 차분 업데이트
+type DepthUpdate struct {
+	EventTime       int64
+	TransactionTime int64
+	Symbol          string
+	// U: 이 이벤트의 첫 update id, u: 마지막 update id, pu: 직전 이벤트의 마지막 update id
+	FirstUpdateID     int64
+	FinalUpdateID     int64
+	PrevFinalUpdateID int64
+	Bids              []PriceLevel
+	Asks              []PriceLevel
+}
+
+func ParseDepthUpdate(data []byte) (DepthUpdate, error) {
+	// encoding/json은 대소문자를 구분하지 않으므로 U/u, e/E를 모두 명시
+	var raw struct {
+		EventType         string         `json:"e"`
+		EventTime         int64          `json:"E"`
+		TransactionTime   int64          `json:"T"`
+		Symbol            string         `json:"s"`
+		FirstUpdateID     int64          `json:"U"`
+		FinalUpdateID     int64          `json:"u"`
+		PrevFinalUpdateID int64          `json:"pu"`
+		Bids              rawPriceLevels `json:"b"`
+		Asks              rawPriceLevels `json:"a"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return DepthUpdate{}, fmt.Errorf("parsing depth update: %w", err)
+	}
+	if raw.EventType != "depthUpdate" {
+		return DepthUpdate{}, fmt.Errorf("unexpected event type: %s", raw.EventType)
+	}
+
+	update := DepthUpdate{
+		EventTime:         raw.EventTime,
+		TransactionTime:   raw.TransactionTime,
+		Symbol:            raw.Symbol,
+		FirstUpdateID:     raw.FirstUpdateID,
+		FinalUpdateID:     raw.FinalUpdateID,
+		PrevFinalUpdateID: raw.PrevFinalUpdateID,
+	}
+
+	var err error
+	if update.Bids, err = raw.Bids.levels(); err != nil {
+		return DepthUpdate{}, fmt.Errorf("parsing bids: %w", err)
+	}
+	if update.Asks, err = raw.Asks.levels(); err != nil {
+		return DepthUpdate{}, fmt.Errorf("parsing asks: %w", err)
+	}
+
+	return update, nil
+}
+
+// 스냅샷 + @depth 차분 스트림으로 유지하는 로컬 호가창
+// Binance 동기화 규칙:
+//  1. u < 스냅샷 lastUpdateId 인 이벤트는 버림
+//  2. 첫 이벤트는 U <= lastUpdateId <= u 여야 함
+//  3. 이후 이벤트의 pu는 직전 이벤트의 u와 같아야 하고, 아니면 스냅샷부터 다시 동기화
+type LocalOrderBook struct {
+	Symbol        string
+	SnapshotLimit int
+
+	client *FutureClient
+
+	mu sync.RWMutex
+
+	// 가격 문자열(decimal.String, 끝자리 0 제거)을 키로 한 호가
+	bids         map[string]PriceLevel
+	asks         map[string]PriceLevel
+	lastUpdateID int64
+	// 스냅샷 이후 첫 이벤트를 적용했으면 true
+	synced          bool
+	eventTime       int64
+	transactionTime int64
+}
+
+func (f *FutureClient) NewLocalOrderBook(symbol string) *LocalOrderBook {
+	return &LocalOrderBook{
+		Symbol:        symbol,
+		SnapshotLimit: defaultOrderBookSnapshotLimit,
+		client:        f,
+		bids:          make(map[string]PriceLevel),
+		asks:          make(map[string]PriceLevel),
+	}
+}
+
+// ctx가 취소될 때까지 호가창 유지
+// 업데이트가 끊기면(재연결 포함) 스냅샷부터 다시 동기화
+func (b *LocalOrderBook) Run(ctx context.Context) error {
+	updates := make(chan DepthUpdate, 1000)
+
+	stream := b.client.NewStream()
+	if err := stream.Subscribe(strings.ToLower(b.Symbol) + "@depth"); err != nil {
+		return err
+	}
+
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- stream.Run(ctx, func(name string, data json.RawMessage) {
+			update, err := ParseDepthUpdate(data)
+			if err != nil {
+				log.Printf("order book %s: %v", b.Symbol, err)
+				return
+			}
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	// 스트림 이벤트가 쌓이기 시작한 뒤에 스냅샷을 받아야 빈 구간이 생기지 않음
+	needSnapshot := true
+	for {
+		select {
+		case update := <-updates:
+			if needSnapshot {
+				if err := b.loadSnapshot(ctx); err != nil {
+					log.Printf("order book %s: %v", b.Symbol, err)
+					if err := sleepContext(ctx, defaultReconnectDelay); err != nil {
+						return err
+					}
+					continue
+				}
+				needSnapshot = false
+			}
+
+			if err := b.Apply(update); err != nil {
+				log.Printf("order book %s: %v, resyncing", b.Symbol, err)
+				needSnapshot = true
+			}
+
+		case err := <-streamErr:
+			return err
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (b *LocalOrderBook) loadSnapshot(ctx context.Context) error {
+	depth, err := b.client.GetDepthContext(ctx, b.Symbol, b.SnapshotLimit)
+	if err != nil {
+		return fmt.Errorf("loading snapshot: %w", err)
+	}
+
+	b.Reset(depth)
+	return nil
+}
+
+// 스냅샷으로 호가창 초기화
+func (b *LocalOrderBook) Reset(depth *Depth) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bids = make(map[string]PriceLevel, len(depth.Bids))
+	b.asks = make(map[string]PriceLevel, len(depth.Asks))
+	applyLevels(b.bids, depth.Bids)
+	applyLevels(b.asks, depth.Asks)
+	b.lastUpdateID = depth.LastUpdateID
+	b.eventTime = depth.EventTime
+	b.transactionTime = depth.TransactionTime
+	b.synced = false
+}
+
+// 차분 업데이트 적용, 순서가 맞지 않으면 ErrOrderBookGap
+func (b *LocalOrderBook) Apply(update DepthUpdate) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.lastUpdateID == 0 {
+		return fmt.Errorf("%w: no snapshot loaded", ErrOrderBookGap)
+	}
+
+	// 스냅샷보다 오래된 이벤트
+	if update.FinalUpdateID < b.lastUpdateID {
+		return nil
+	}
+
+	if !b.synced {
+		if update.FirstUpdateID > b.lastUpdateID {
+			return fmt.Errorf("%w: first update %d is after snapshot %d", ErrOrderBookGap, update.FirstUpdateID, b.lastUpdateID)
+		}
+		b.synced = true
+	} else if update.PrevFinalUpdateID != b.lastUpdateID {
+		b.synced = false
+		return fmt.Errorf("%w: expected pu %d, got %d", ErrOrderBookGap, b.lastUpdateID, update.PrevFinalUpdateID)
+	}
+
+	applyLevels(b.bids, update.Bids)
+	applyLevels(b.asks, update.Asks)
+	b.lastUpdateID = update.FinalUpdateID
+	b.eventTime = update.EventTime
+	b.transactionTime = update.TransactionTime
+	return nil
+}
+
+// 수량은 절대값, 0이면 해당 가격 삭제
+func applyLevels(side map[string]PriceLevel, levels []PriceLevel) {
+	for _, level := range levels {
+		key := level.Price.String()
+		if level.Quantity.IsZero() {
+			delete(side, key)
+			continue
+		}
+		side[key] = level
+	}
+}
+
+// 스트림과 동기화된 상태인지
+func (b *LocalOrderBook) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// 현재 호가창을 정렬된 Depth로 복사 (limit <= 0 이면 전체)
+func (b *LocalOrderBook) Snapshot(limit int) *Depth {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	depth := &Depth{
+		Symbol:          b.Symbol,
+		LastUpdateID:    b.lastUpdateID,
+		EventTime:       b.eventTime,
+		TransactionTime: b.transactionTime,
+		Bids:            sortedLevels(b.bids, true, limit),
+		Asks:            sortedLevels(b.asks, false, limit),
+	}
+	return depth
+}
+
+func sortedLevels(side map[string]PriceLevel, descending bool, limit int) []PriceLevel {
+	levels := make([]PriceLevel, 0, len(side))
+	for _, level := range side {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if descending {
+			return levels[i].Price.GreaterThan(levels[j].Price)
+		}
+		return levels[i].Price.LessThan(levels[j].Price)
+	})
+	if limit > 0 && len(levels) > limit {
+		levels = levels[:limit]
+	}
+	return levels
+}
+
+func (b *LocalOrderBook) BestBid() (PriceLevel, bool) {
+	return b.Snapshot(1).BestBid()
+}
+
+func (b *LocalOrderBook) BestAsk() (PriceLevel, bool) {
+	return b.Snapshot(1).BestAsk()
+}
+
+func (b *LocalOrderBook) Spread() (spread decimal.Decimal, percent decimal.Decimal, ok bool) {
+	return b.Snapshot(1).Spread()
+}
+
+// 중간 가격에서 percent% 이내 호가의 누적 잔량
+func (b *LocalOrderBook) DepthWithin(percent decimal.Decimal) DepthSummary {
+	return b.Snapshot(0).DepthWithin(percent)
+}
@@ -0,0 +1,191 @@
+package futures
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func level(price, quantity string) PriceLevel {
+	return PriceLevel{Price: decimal.RequireFromString(price), Quantity: decimal.RequireFromString(quantity)}
+}
+
+// 스냅샷 lastUpdateId = 100
+func snapshotBook() *LocalOrderBook {
+	book := &LocalOrderBook{Symbol: "BTCUSDT"}
+	book.Reset(&Depth{
+		Symbol:       "BTCUSDT",
+		LastUpdateID: 100,
+		Bids:         []PriceLevel{level("100.0", "1")},
+		Asks:         []PriceLevel{level("101.0", "1")},
+	})
+	return book
+}
+
+func depthUpdate(first, final, prev int64, bids ...PriceLevel) DepthUpdate {
+	return DepthUpdate{
+		Symbol:            "BTCUSDT",
+		FirstUpdateID:     first,
+		FinalUpdateID:     final,
+		PrevFinalUpdateID: prev,
+		Bids:              bids,
+	}
+}
+
+func TestLocalOrderBookApplySequence(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates []DepthUpdate
+		// 마지막 업데이트 결과가 ErrOrderBookGap이어야 하면 true, 나머지는 모두 성공해야 함
+		wantGap    bool
+		wantSynced bool
+		wantLastID int64
+	}{
+		{
+			name:       "stale event before snapshot is dropped",
+			updates:    []DepthUpdate{depthUpdate(90, 99, 89, level("100.0", "9"))},
+			wantSynced: false,
+			wantLastID: 100,
+		},
+		{
+			name:       "first event straddles snapshot",
+			updates:    []DepthUpdate{depthUpdate(95, 105, 94)},
+			wantSynced: true,
+			wantLastID: 105,
+		},
+		{
+			name:       "first event ends exactly at snapshot",
+			updates:    []DepthUpdate{depthUpdate(98, 100, 97)},
+			wantSynced: true,
+			wantLastID: 100,
+		},
+		{
+			name:       "first event starts exactly at snapshot",
+			updates:    []DepthUpdate{depthUpdate(100, 103, 99)},
+			wantSynced: true,
+			wantLastID: 103,
+		},
+		{
+			name: "stale events then straddling event",
+			updates: []DepthUpdate{
+				depthUpdate(80, 90, 79),
+				depthUpdate(91, 99, 90),
+				depthUpdate(100, 110, 99),
+			},
+			wantSynced: true,
+			wantLastID: 110,
+		},
+		{
+			name:       "first event after snapshot is a gap",
+			updates:    []DepthUpdate{depthUpdate(101, 110, 100)},
+			wantGap:    true,
+			wantSynced: false,
+			wantLastID: 100,
+		},
+		{
+			name: "chained events with matching pu",
+			updates: []DepthUpdate{
+				depthUpdate(95, 105, 94),
+				depthUpdate(106, 110, 105),
+				depthUpdate(111, 120, 110),
+			},
+			wantSynced: true,
+			wantLastID: 120,
+		},
+		{
+			name: "pu mismatch is a gap",
+			updates: []DepthUpdate{
+				depthUpdate(95, 105, 94),
+				depthUpdate(108, 112, 107),
+			},
+			wantGap:    true,
+			wantSynced: false,
+			wantLastID: 105,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := snapshotBook()
+			for i, update := range tt.updates {
+				err := book.Apply(update)
+				if i < len(tt.updates)-1 || !tt.wantGap {
+					if err != nil {
+						t.Fatalf("Apply(#%d) = %v, want nil", i, err)
+					}
+					continue
+				}
+				if !errors.Is(err, ErrOrderBookGap) {
+					t.Fatalf("Apply(#%d) = %v, want ErrOrderBookGap", i, err)
+				}
+			}
+			if got := book.Synced(); got != tt.wantSynced {
+				t.Errorf("Synced() = %v, want %v", got, tt.wantSynced)
+			}
+			if got := book.Snapshot(0).LastUpdateID; got != tt.wantLastID {
+				t.Errorf("LastUpdateID = %d, want %d", got, tt.wantLastID)
+			}
+		})
+	}
+}
+
+func TestLocalOrderBookApplyWithoutSnapshot(t *testing.T) {
+	book := &LocalOrderBook{Symbol: "BTCUSDT"}
+	if err := book.Apply(depthUpdate(1, 2, 0)); !errors.Is(err, ErrOrderBookGap) {
+		t.Errorf("Apply before Reset = %v, want ErrOrderBookGap", err)
+	}
+}
+
+func TestLocalOrderBookStaleEventDoesNotChangeLevels(t *testing.T) {
+	book := snapshotBook()
+	if err := book.Apply(depthUpdate(90, 99, 89, level("100.0", "9"), level("99.0", "5"))); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	bids := book.Snapshot(0).Bids
+	if len(bids) != 1 || !bids[0].Quantity.Equal(decimal.RequireFromString("1")) {
+		t.Errorf("bids = %v, want the snapshot level only", bids)
+	}
+}
+
+func TestLocalOrderBookResyncAfterGap(t *testing.T) {
+	book := snapshotBook()
+	if err := book.Apply(depthUpdate(95, 105, 94, level("100.0", "2"))); err != nil {
+		t.Fatalf("first Apply: %v", err)
+	}
+	if err := book.Apply(depthUpdate(110, 115, 109)); !errors.Is(err, ErrOrderBookGap) {
+		t.Fatalf("Apply with missing events = %v, want ErrOrderBookGap", err)
+	}
+
+	// Run과 같은 경로: 새 스냅샷을 받고 다시 첫 이벤트부터 맞춤
+	book.Reset(&Depth{
+		Symbol:       "BTCUSDT",
+		LastUpdateID: 120,
+		Bids:         []PriceLevel{level("100.5", "3")},
+		Asks:         []PriceLevel{level("101.0", "4")},
+	})
+	if book.Synced() {
+		t.Fatal("Synced() = true right after Reset")
+	}
+	steps := []DepthUpdate{
+		depthUpdate(110, 115, 109),                         // 새 스냅샷보다 오래됨
+		depthUpdate(118, 125, 117, level("100.5", "0")),    // 스냅샷을 걸침, 100.5 삭제
+		depthUpdate(126, 130, 125, level("100.10", "1.5")), // pu 일치
+	}
+	for i, update := range steps {
+		if err := book.Apply(update); err != nil {
+			t.Fatalf("Apply(#%d) after resync: %v", i, err)
+		}
+	}
+	if !book.Synced() {
+		t.Error("Synced() = false after resync")
+	}
+
+	bid, ok := book.BestBid()
+	if !ok || !bid.Price.Equal(decimal.RequireFromString("100.1")) || !bid.Quantity.Equal(decimal.RequireFromString("1.5")) {
+		t.Errorf("BestBid() = %v, %v, want 100.1 x 1.5", bid, ok)
+	}
+	if got := book.Snapshot(0).LastUpdateID; got != 130 {
+		t.Errorf("LastUpdateID = %d, want 130", got)
+	}
+}
@@ -0,0 +1,163 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+// 주문 조회/취소 결과
+type Order struct {
+	OrderID       int64           `json:"orderId"`
+	ClientOrderID string          `json:"clientOrderId"`
+	Symbol        string          `json:"symbol"`
+	Status        string          `json:"status"`
+	Side          OrderSide       `json:"side"`
+	PositionSide  PositionSide    `json:"positionSide"`
+	Type          string          `json:"type"`
+	OrigType      string          `json:"origType"`
+	TimeInForce   string          `json:"timeInForce"`
+	Price         decimal.Decimal `json:"price"`
+	AvgPrice      decimal.Decimal `json:"avgPrice"`
+	StopPrice     decimal.Decimal `json:"stopPrice"`
+	OrigQty       decimal.Decimal `json:"origQty"`
+	ExecutedQty   decimal.Decimal `json:"executedQty"`
+	CumQuote      decimal.Decimal `json:"cumQuote"`
+	ReduceOnly    bool            `json:"reduceOnly"`
+	ClosePosition bool            `json:"closePosition"`
+	WorkingType   string          `json:"workingType"`
+	Time          int64           `json:"time"`
+	UpdateTime    int64           `json:"updateTime"`
+}
+
+func (o *Order) response() *OrderResponse {
+	return &OrderResponse{
+		OrderID:       o.OrderID,
+		ClientOrderID: o.ClientOrderID,
+		Symbol:        o.Symbol,
+		Status:        o.Status,
+		Side:          o.Side,
+		PositionSide:  o.PositionSide,
+		Type:          o.Type,
+		OrigQty:       o.OrigQty,
+		ExecutedQty:   o.ExecutedQty,
+		AvgPrice:      o.AvgPrice,
+		UpdateTime:    o.UpdateTime,
+	}
+}
+
+// 주문 ID로 주문 조회
+func (f *FutureClient) QueryOrder(symbol string, orderID int64) (*Order, error) {
+	return f.QueryOrderContext(context.Background(), symbol, orderID)
+}
+
+func (f *FutureClient) QueryOrderContext(ctx context.Context, symbol string, orderID int64) (*Order, error) {
+	params := url.Values{}
+	params.Add("symbol", symbol)
+	params.Add("orderId", strconv.FormatInt(orderID, 10))
+
+	return f.requestOrder(ctx, "GET", params)
+}
+
+// clientOrderId로 주문 조회
+func (f *FutureClient) QueryOrderByClientID(symbol, clientOrderID string) (*Order, error) {
+	return f.QueryOrderByClientIDContext(context.Background(), symbol, clientOrderID)
+}
+
+func (f *FutureClient) QueryOrderByClientIDContext(ctx context.Context, symbol, clientOrderID string) (*Order, error) {
+	params := url.Values{}
+	params.Add("symbol", symbol)
+	params.Add("origClientOrderId", clientOrderID)
+
+	return f.requestOrder(ctx, "GET", params)
+}
+
+// 주문 ID로 주문 취소
+func (f *FutureClient) CancelOrder(symbol string, orderID int64) (*Order, error) {
+	return f.CancelOrderContext(context.Background(), symbol, orderID)
+}
+
+func (f *FutureClient) CancelOrderContext(ctx context.Context, symbol string, orderID int64) (*Order, error) {
+	params := url.Values{}
+	params.Add("symbol", symbol)
+	params.Add("orderId", strconv.FormatInt(orderID, 10))
+
+	return f.requestOrder(ctx, "DELETE", params)
+}
+
+// 심볼의 미체결 주문 전체 취소
+func (f *FutureClient) CancelAllOpenOrders(symbol string) error {
+	return f.CancelAllOpenOrdersContext(context.Background(), symbol)
+}
+
+func (f *FutureClient) CancelAllOpenOrdersContext(ctx context.Context, symbol string) error {
+	params := url.Values{}
+	params.Add("symbol", symbol)
+
+	if _, err := f.doSignedRequest(ctx, "DELETE", "/fapi/v1/allOpenOrders", params); err != nil {
+		return fmt.Errorf("cancelling all open orders: %w", err)
+	}
+	return nil
+}
+
+// 심볼의 미체결 주문 조회 (걸려 있는 TP/SL 포함)
+// symbol이 빈 문자열이면 전체 심볼 조회
+func (f *FutureClient) GetOpenOrders(symbol string) ([]Order, error) {
+	return f.GetOpenOrdersContext(context.Background(), symbol)
+}
+
+func (f *FutureClient) GetOpenOrdersContext(ctx context.Context, symbol string) ([]Order, error) {
+	params := url.Values{}
+	if symbol != "" {
+		params.Add("symbol", symbol)
+	}
+
+	return f.requestOrders(ctx, "/fapi/v1/openOrders", params)
+}
+
+// 심볼의 전체 주문 내역 조회 (최근 limit개, 최대 1000)
+func (f *FutureClient) GetAllOrders(symbol string, limit int) ([]Order, error) {
+	return f.GetAllOrdersContext(context.Background(), symbol, limit)
+}
+
+func (f *FutureClient) GetAllOrdersContext(ctx context.Context, symbol string, limit int) ([]Order, error) {
+	params := url.Values{}
+	params.Add("symbol", symbol)
+	if limit > 0 {
+		params.Add("limit", strconv.Itoa(limit))
+	}
+
+	return f.requestOrders(ctx, "/fapi/v1/allOrders", params)
+}
+
+func (f *FutureClient) requestOrder(ctx context.Context, method string, params url.Values) (*Order, error) {
+	body, err := f.doSignedRequest(ctx, method, "/fapi/v1/order", params)
+	if err != nil {
+		return nil, fmt.Errorf("order request failed: %w", err)
+	}
+
+	var order Order
+	if err := json.Unmarshal(body, &order); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return &order, nil
+}
+
+func (f *FutureClient) requestOrders(ctx context.Context, endpoint string, params url.Values) ([]Order, error) {
+	body, err := f.doSignedRequest(ctx, "GET", endpoint, params)
+	if err != nil {
+		return nil, fmt.Errorf("getting orders failed: %w", err)
+	}
+
+	var orders []Order
+	if err := json.Unmarshal(body, &orders); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return orders, nil
+}
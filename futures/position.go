@@ -0,0 +1,94 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/shopspring/decimal"
+)
+
+type Position struct {
+	Symbol           string          `json:"symbol"`
+	PositionSide     PositionSide    `json:"positionSide"`
+	PositionAmt      decimal.Decimal `json:"positionAmt"`
+	EntryPrice       decimal.Decimal `json:"entryPrice"`
+	MarkPrice        decimal.Decimal `json:"markPrice"`
+	UnRealizedProfit decimal.Decimal `json:"unRealizedProfit"`
+	LiquidationPrice decimal.Decimal `json:"liquidationPrice"`
+	Leverage         int             `json:"leverage,string"`
+	MarginType       string          `json:"marginType"`
+	IsolatedMargin   decimal.Decimal `json:"isolatedMargin"`
+	Notional         decimal.Decimal `json:"notional"`
+	UpdateTime       int64           `json:"updateTime"`
+}
+
+// 포지션 수량이 0이 아니면 열린 포지션
+func (p Position) IsOpen() bool {
+	return !p.PositionAmt.IsZero()
+}
+
+// 포지션 조회 (symbol이 빈 문자열이면 전체 심볼)
+// Hedge 모드는 심볼당 LONG/SHORT 두 개, One-way 모드는 BOTH 하나가 내려옴
+func (f *FutureClient) GetPositions(symbol string) ([]Position, error) {
+	return f.GetPositionsContext(context.Background(), symbol)
+}
+
+func (f *FutureClient) GetPositionsContext(ctx context.Context, symbol string) ([]Position, error) {
+	params := url.Values{}
+	if symbol != "" {
+		params.Add("symbol", symbol)
+	}
+
+	body, err := f.doSignedRequest(ctx, "GET", "/fapi/v2/positionRisk", params)
+	if err != nil {
+		return nil, fmt.Errorf("getting positions failed: %w", err)
+	}
+
+	var positions []Position
+	if err := json.Unmarshal(body, &positions); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return positions, nil
+}
+
+// 심볼의 특정 방향 포지션 조회
+// One-way 모드(BOTH)에서는 수량 부호로 LONG/SHORT를 판단하고, 방향이 다르면 수량 0인 포지션을 반환
+func (f *FutureClient) GetPosition(symbol string, side PositionSide) (*Position, error) {
+	return f.GetPositionContext(context.Background(), symbol, side)
+}
+
+func (f *FutureClient) GetPositionContext(ctx context.Context, symbol string, side PositionSide) (*Position, error) {
+	positions, err := f.GetPositionsContext(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range positions {
+		if p.Symbol != symbol {
+			continue
+		}
+
+		if p.PositionSide == side {
+			return &p, nil
+		}
+
+		if p.PositionSide == BOTH {
+			if (side == LONG && p.PositionAmt.IsPositive()) || (side == SHORT && p.PositionAmt.IsNegative()) {
+				return &p, nil
+			}
+			return &Position{
+				Symbol:       p.Symbol,
+				PositionSide: side,
+				MarkPrice:    p.MarkPrice,
+				Leverage:     p.Leverage,
+				MarginType:   p.MarginType,
+				UpdateTime:   p.UpdateTime,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("position not found: %s %s", symbol, side)
+}
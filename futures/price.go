@@ -1,66 +1,34 @@
 package futures
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"math"
-	"net/http"
 	"net/url"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
-func FloorToStepSize(quantity, stepSize float64) float64 {
-	precision := GetPrecisionFromStepSize(stepSize)
-	factor := math.Pow10(precision)
-	return math.Floor(quantity*factor) / factor
-}
-
-func GetPrecisionFromStepSize(stepSize float64) int {
-	stepStr := fmt.Sprint(stepSize)
-	if strings.Contains(stepStr, "e") {
-		// Handle scientific notation
-		parts := strings.Split(stepStr, "e-")
-		if len(parts) == 2 {
-			precision, err := strconv.Atoi(parts[1])
-			if err == nil {
-				return precision
-			}
-		}
-	}
+// 요청 가중치 대비 가장 많은 캔들을 받는 limit (1000개까지 가중치 5)
+const klineRangePageLimit = 1000
 
-	parts := strings.Split(stepStr, ".")
-	if len(parts) < 2 {
-		return 0
-	}
-	return len(parts[1])
-}
 func (f *FutureClient) GetTopVolumeSymbols(n int) ([]string, error) {
-	endpoint := "/fapi/v1/ticker/24hr"
-
-	req, err := http.NewRequest("GET", f.BaseURL+endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
+	return f.GetTopVolumeSymbolsContext(context.Background(), n)
+}
 
-	resp, err := http.DefaultClient.Do(req)
+func (f *FutureClient) GetTopVolumeSymbolsContext(ctx context.Context, n int) ([]string, error) {
+	body, err := f.doRequest(ctx, "GET", "/fapi/v1/ticker/24hr", nil, false)
 	if err != nil {
-		return nil, fmt.Errorf("sending request: %w", err)
+		return nil, fmt.Errorf("getting 24hr tickers: %w", err)
 	}
-	defer resp.Body.Close()
 
 	var tickers []struct {
 		Symbol      string  `json:"symbol"`
 		QuoteVolume float64 `json:"quoteVolume,string"`
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
-	}
-
 	if err := json.Unmarshal(body, &tickers); err != nil {
 		return nil, fmt.Errorf("parsing response: %w", err)
 	}
@@ -105,85 +73,93 @@ func (f *FutureClient) GetTopVolumeSymbols(n int) ([]string, error) {
 }
 
 func (f *FutureClient) GetKlineData(symbol string, interval string, limit int) ([]CandleData, error) {
+	return f.GetKlineDataContext(context.Background(), symbol, interval, limit)
+}
+
+func (f *FutureClient) GetKlineDataContext(ctx context.Context, symbol string, interval string, limit int) ([]CandleData, error) {
 	params := url.Values{}
 	params.Add("symbol", symbol)
 	params.Add("interval", interval)
 	params.Add("limit", strconv.Itoa(limit))
 
-	endpoint := "/fapi/v1/klines"
-	req, err := http.NewRequest("GET", f.BaseURL+endpoint+"?"+params.Encode(), nil)
+	body, err := f.doRequest(ctx, "GET", "/fapi/v1/klines", params, false)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, fmt.Errorf("getting klines: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("sending request: %w", err)
-	}
-	defer resp.Body.Close()
+	return parseKlines(body)
+}
 
-	var rawCandles [][]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&rawCandles); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
+// 기간 내 캔들 전체 조회
+// 한 번에 최대 klineRangePageLimit개씩 startTime을 옮겨가며 받아오고, 겹치는 캔들은 OpenTime 기준으로 제거
+// 요청 가중치는 공통 rate limiter가 관리하므로 긴 기간도 한도 안에서 나눠서 받음
+func (f *FutureClient) GetKlineRange(symbol, interval string, start, end time.Time) ([]CandleData, error) {
+	return f.GetKlineRangeContext(context.Background(), symbol, interval, start, end)
+}
 
-	candles := make([]CandleData, len(rawCandles))
-	for i, raw := range rawCandles {
-		candles[i] = CandleData{
-			OpenTime:                 int64(raw[0].(float64)),
-			Open:                     raw[1].(string),
-			High:                     raw[2].(string),
-			Low:                      raw[3].(string),
-			Close:                    raw[4].(string),
-			Volume:                   raw[5].(string),
-			CloseTime:                int64(raw[6].(float64)),
-			QuoteAssetVolume:         raw[7].(string),
-			NumberOfTrades:           int(raw[8].(float64)),
-			TakerBuyBaseAssetVolume:  raw[9].(string),
-			TakerBuyQuoteAssetVolume: raw[10].(string),
-		}
+func (f *FutureClient) GetKlineRangeContext(ctx context.Context, symbol, interval string, start, end time.Time) ([]CandleData, error) {
+	if end.Before(start) {
+		return nil, fmt.Errorf("invalid range: end %s is before start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
 	}
 
-	return candles, nil
-}
+	endTime := end.UnixMilli()
+	cursor := start.UnixMilli()
 
-func (f *FutureClient) GetWalletBalance() (map[string]Balance, error) {
-	// 서버 시간 가져오기
-	serverTime, err := f.GetServerTime()
-	if err != nil {
-		return nil, fmt.Errorf("getting server time: %w", err)
-	}
+	var candles []CandleData
+	for cursor <= endTime {
+		params := url.Values{}
+		params.Add("symbol", symbol)
+		params.Add("interval", interval)
+		params.Add("startTime", strconv.FormatInt(cursor, 10))
+		params.Add("endTime", strconv.FormatInt(endTime, 10))
+		params.Add("limit", strconv.Itoa(klineRangePageLimit))
 
-	params := url.Values{}
-	params.Add("timestamp", strconv.FormatInt(serverTime, 10))
-	params.Add("recvWindow", "10000")
+		body, err := f.doRequest(ctx, "GET", "/fapi/v1/klines", params, false)
+		if err != nil {
+			return nil, fmt.Errorf("getting klines from %d: %w", cursor, err)
+		}
 
-	signature := f.sign(params.Encode())
-	params.Add("signature", signature)
+		page, err := parseKlines(body)
+		if err != nil {
+			return nil, err
+		}
 
-	req, err := http.NewRequest("GET", f.BaseURL+"/fapi/v2/account?"+params.Encode(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
+		for _, candle := range page {
+			if len(candles) > 0 && candle.OpenTime <= candles[len(candles)-1].OpenTime {
+				continue
+			}
+			candles = append(candles, candle)
+		}
 
-	req.Header.Set("X-MBX-APIKEY", f.APIKey)
+		if len(page) < klineRangePageLimit {
+			break
+		}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("sending request: %w", err)
+		next := page[len(page)-1].OpenTime + 1
+		if next <= cursor {
+			break
+		}
+		cursor = next
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("getting balance failed: %s", string(body))
+	return candles, nil
+}
+
+func (f *FutureClient) GetWalletBalance() (map[string]Balance, error) {
+	return f.GetWalletBalanceContext(context.Background())
+}
+
+func (f *FutureClient) GetWalletBalanceContext(ctx context.Context) (map[string]Balance, error) {
+	body, err := f.doSignedRequest(ctx, "GET", "/fapi/v2/account", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting balance failed: %w", err)
 	}
 
 	var accountInfo struct {
 		Assets []AccountBalance `json:"assets"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&accountInfo); err != nil {
+	if err := json.Unmarshal(body, &accountInfo); err != nil {
 		return nil, fmt.Errorf("parsing response: %w", err)
 	}
 
@@ -191,7 +167,7 @@ func (f *FutureClient) GetWalletBalance() (map[string]Balance, error) {
 	for _, asset := range accountInfo.Assets {
 		balances[asset.Asset] = Balance{
 			Free:   asset.AvailableBalance,
-			Locked: asset.WalletBalance - asset.AvailableBalance,
+			Locked: asset.WalletBalance.Sub(asset.AvailableBalance),
 		}
 	}
 
@@ -0,0 +1,206 @@
+package futures
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// USDⓈ-M 선물 기본 한도
+	defaultWeightLimit1m   = 2400
+	defaultOrderLimit10s   = 300
+	defaultOrderLimit1m    = 1200
+	defaultRetryAfter      = 10 * time.Second
+	rateLimitSafetyPercent = 90
+)
+
+// IP 단위로 적용되는 요청 가중치, 주문 수 추적
+// 같은 BaseURL을 쓰는 FutureClient끼리 공유
+type rateLimiter struct {
+	mu sync.Mutex
+
+	weightLimit   int
+	orderLimit10s int
+	orderLimit1m  int
+
+	usedWeight   int
+	orderCount10 int
+	orderCount1m int
+	// 마지막으로 헤더를 받은 시각
+	updatedAt time.Time
+
+	// 429 Retry-After 까지 대기
+	retryAfter time.Time
+	// 418 차단 해제 시각, 그 전까지 모든 요청 중단
+	bannedUntil time.Time
+}
+
+var (
+	rateLimitersMu sync.Mutex
+	rateLimiters   = map[string]*rateLimiter{}
+)
+
+func (f *FutureClient) limiter() *rateLimiter {
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+
+	limiter, ok := rateLimiters[f.BaseURL]
+	if !ok {
+		limiter = &rateLimiter{
+			weightLimit:   defaultWeightLimit1m,
+			orderLimit10s: defaultOrderLimit10s,
+			orderLimit1m:  defaultOrderLimit1m,
+		}
+		rateLimiters[f.BaseURL] = limiter
+	}
+	return limiter
+}
+
+// 요청 전에 한도를 넘지 않도록 대기
+// IP 차단 중이면 대기하지 않고 바로 ErrIPBanned 반환
+func (l *rateLimiter) wait(ctx context.Context, weight int, isOrder bool) error {
+	for {
+		delay, err := l.reserve(weight, isOrder)
+		if err != nil {
+			return err
+		}
+		if delay <= 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *rateLimiter) reserve(weight int, isOrder bool) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.bannedUntil) {
+		return 0, fmt.Errorf("%w until %s", ErrIPBanned, l.bannedUntil.Format(time.RFC3339))
+	}
+	if now.Before(l.retryAfter) {
+		return l.retryAfter.Sub(now), nil
+	}
+
+	// 헤더 값은 분/10초 단위 윈도우 기준이라 윈도우가 바뀌면 초기화
+	if now.Truncate(time.Minute).After(l.updatedAt) {
+		l.usedWeight = 0
+		l.orderCount1m = 0
+	}
+	if now.Truncate(10 * time.Second).After(l.updatedAt) {
+		l.orderCount10 = 0
+	}
+
+	if l.usedWeight+weight > l.weightLimit*rateLimitSafetyPercent/100 {
+		return now.Truncate(time.Minute).Add(time.Minute).Sub(now), nil
+	}
+	if isOrder {
+		if l.orderCount10+1 > l.orderLimit10s*rateLimitSafetyPercent/100 {
+			return now.Truncate(10 * time.Second).Add(10 * time.Second).Sub(now), nil
+		}
+		if l.orderCount1m+1 > l.orderLimit1m*rateLimitSafetyPercent/100 {
+			return now.Truncate(time.Minute).Add(time.Minute).Sub(now), nil
+		}
+		l.orderCount10++
+		l.orderCount1m++
+	}
+
+	// 응답 헤더가 오기 전 동시 요청도 고려해 미리 더해둠
+	l.usedWeight += weight
+	l.updatedAt = now
+	return 0, nil
+}
+
+// 응답 헤더로 사용량 갱신, 429/418이면 대기/차단 시각 기록
+func (l *rateLimiter) update(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if used, err := strconv.Atoi(resp.Header.Get("X-MBX-USED-WEIGHT-1M")); err == nil {
+		l.usedWeight = used
+		l.updatedAt = now
+	}
+	if count, err := strconv.Atoi(resp.Header.Get("X-MBX-ORDER-COUNT-10S")); err == nil {
+		l.orderCount10 = count
+	}
+	if count, err := strconv.Atoi(resp.Header.Get("X-MBX-ORDER-COUNT-1M")); err == nil {
+		l.orderCount1m = count
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		l.retryAfter = now.Add(parseRetryAfter(resp.Header.Get("Retry-After")))
+	case http.StatusTeapot:
+		l.bannedUntil = now.Add(parseRetryAfter(resp.Header.Get("Retry-After")))
+	}
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultRetryAfter
+}
+
+// 엔드포인트별 요청 가중치
+func requestWeight(endpoint string, params url.Values) int {
+	switch endpoint {
+	case "/fapi/v1/ticker/24hr", "/fapi/v1/openOrders":
+		if params.Get("symbol") == "" {
+			return 40
+		}
+		return 1
+	case "/fapi/v1/klines":
+		limit, err := strconv.Atoi(params.Get("limit"))
+		if err != nil {
+			limit = 500
+		}
+		switch {
+		case limit < 100:
+			return 1
+		case limit < 500:
+			return 2
+		case limit <= 1000:
+			return 5
+		default:
+			return 10
+		}
+	case "/fapi/v1/depth":
+		limit, err := strconv.Atoi(params.Get("limit"))
+		if err != nil {
+			limit = 500
+		}
+		switch {
+		case limit <= 50:
+			return 2
+		case limit <= 100:
+			return 5
+		case limit <= 500:
+			return 10
+		default:
+			return 20
+		}
+	case "/fapi/v1/premiumIndex":
+		if params.Get("symbol") == "" {
+			return 10
+		}
+		return 1
+	case "/fapi/v2/account", "/fapi/v2/positionRisk", "/fapi/v1/allOrders", "/fapi/v1/batchOrders":
+		return 5
+	}
+
+	return 1
+}
+
+func isOrderRequest(method, endpoint string) bool {
+	return method == http.MethodPost && (endpoint == "/fapi/v1/order" || endpoint == "/fapi/v1/batchOrders")
+}
@@ -0,0 +1,221 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultPingInterval   = time.Minute
+	defaultReconnectDelay = 5 * time.Second
+)
+
+// 결합 스트림(/stream) 연결 관리
+// 여러 스트림을 한 연결로 구독하고, 연결이 끊기면 다시 연결해 구독을 복구
+type Stream struct {
+	BaseURL        string
+	Dialer         *websocket.Dialer
+	PingInterval   time.Duration
+	ReconnectDelay time.Duration
+
+	mu      sync.Mutex
+	streams map[string]bool
+	conn    *websocket.Conn
+	nextID  int64
+}
+
+// 결합 스트림 메시지
+type streamMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+func NewStream(baseURL string) *Stream {
+	return &Stream{
+		BaseURL:        strings.TrimRight(baseURL, "/"),
+		Dialer:         websocket.DefaultDialer,
+		PingInterval:   defaultPingInterval,
+		ReconnectDelay: defaultReconnectDelay,
+		streams:        make(map[string]bool),
+	}
+}
+
+// 클라이언트 접속 환경의 WebSocket 주소로 스트림 생성
+func (f *FutureClient) NewStream() *Stream {
+	return NewStream(f.Environment.WSBaseURL)
+}
+
+// 스트림 구독 (연결 중이면 바로 SUBSCRIBE 전송, 아니면 다음 연결 때 구독)
+func (s *Stream) Subscribe(names ...string) error {
+	return s.update("SUBSCRIBE", names, true)
+}
+
+// 스트림 구독 해제
+func (s *Stream) Unsubscribe(names ...string) error {
+	return s.update("UNSUBSCRIBE", names, false)
+}
+
+// 현재 구독 중인 스트림 이름
+func (s *Stream) Streams() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.streams))
+	for name := range s.streams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Stream) update(method string, names []string, subscribe bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changed []string
+	for _, name := range names {
+		if s.streams[name] == subscribe {
+			continue
+		}
+		if subscribe {
+			s.streams[name] = true
+		} else {
+			delete(s.streams, name)
+		}
+		changed = append(changed, name)
+	}
+
+	if len(changed) == 0 || s.conn == nil {
+		return nil
+	}
+
+	s.nextID++
+	request := map[string]interface{}{
+		"method": method,
+		"params": changed,
+		"id":     s.nextID,
+	}
+	if err := s.conn.WriteJSON(request); err != nil {
+		return fmt.Errorf("sending %s: %w", strings.ToLower(method), err)
+	}
+	return nil
+}
+
+// ctx가 취소될 때까지 메시지를 handler로 전달
+// 연결이 끊기면 ReconnectDelay 후 다시 연결
+func (s *Stream) Run(ctx context.Context, handler func(stream string, data json.RawMessage)) error {
+	for {
+		err := s.runOnce(ctx, handler)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		log.Printf("stream disconnected, reconnecting in %v: %v", s.ReconnectDelay, err)
+		if err := sleepContext(ctx, s.ReconnectDelay); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Stream) runOnce(ctx context.Context, handler func(stream string, data json.RawMessage)) error {
+	conn, _, err := s.Dialer.DialContext(ctx, s.BaseURL+"/stream", http.Header{})
+	if err != nil {
+		return fmt.Errorf("dialing stream: %w", err)
+	}
+	defer conn.Close()
+
+	readTimeout := 3 * s.PingInterval
+	conn.SetReadDeadline(time.Now().Add(readTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+	// 서버 ping에는 pong으로 응답하고 read deadline 연장
+	conn.SetPingHandler(func(data string) error {
+		conn.SetReadDeadline(time.Now().Add(readTimeout))
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(10*time.Second))
+		if err == websocket.ErrCloseSent {
+			return nil
+		}
+		return err
+	})
+
+	// 기존 구독 복구
+	s.mu.Lock()
+	s.conn = conn
+	var names []string
+	for name := range s.streams {
+		names = append(names, name)
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		s.nextID++
+		err = conn.WriteJSON(map[string]interface{}{
+			"method": "SUBSCRIBE",
+			"params": names,
+			"id":     s.nextID,
+		})
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+	}()
+
+	if err != nil {
+		return fmt.Errorf("subscribing streams: %w", err)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(s.PingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+					conn.Close()
+					return
+				}
+			case <-ctx.Done():
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+				conn.Close()
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("reading stream: %w", err)
+		}
+		conn.SetReadDeadline(time.Now().Add(readTimeout))
+
+		var message streamMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			log.Printf("stream: skipping malformed message: %v", err)
+			continue
+		}
+		// SUBSCRIBE 응답 ({"result": null, "id": 1})은 stream이 없음
+		if message.Stream == "" {
+			continue
+		}
+
+		handler(message.Stream, message.Data)
+	}
+}
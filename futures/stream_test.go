@@ -0,0 +1,303 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// 결합 스트림(/stream) 대역 서버
+// 연결마다 받은 SUBSCRIBE 요청을 기록하고, onConnect로 연결별 동작을 지정
+type fakeStreamServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	subscribes [][]string
+	conns      int
+	pongs      int
+	connected  chan *websocket.Conn
+	onConnect  func(conn *websocket.Conn)
+	// true면 클라이언트 ping에 pong으로 응답하지 않음
+	noPong bool
+}
+
+func newFakeStreamServer(t *testing.T, onConnect func(conn *websocket.Conn)) *fakeStreamServer {
+	t.Helper()
+
+	server := &fakeStreamServer{
+		connected: make(chan *websocket.Conn, 16),
+		onConnect: onConnect,
+	}
+	upgrader := websocket.Upgrader{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stream" {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		server.mu.Lock()
+		if server.noPong {
+			conn.SetPingHandler(func(string) error { return nil })
+		}
+		conn.SetPongHandler(func(string) error {
+			server.mu.Lock()
+			server.pongs++
+			server.mu.Unlock()
+			return nil
+		})
+		server.conns++
+		index := len(server.subscribes)
+		server.subscribes = append(server.subscribes, nil)
+		server.mu.Unlock()
+
+		server.connected <- conn
+		if server.onConnect != nil {
+			go server.onConnect(conn)
+		}
+
+		for {
+			var request struct {
+				Method string   `json:"method"`
+				Params []string `json:"params"`
+				ID     int64    `json:"id"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			if request.Method == "SUBSCRIBE" {
+				server.mu.Lock()
+				server.subscribes[index] = append(server.subscribes[index], request.Params...)
+				server.mu.Unlock()
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *fakeStreamServer) wsURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func (s *fakeStreamServer) subscribed(conn int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if conn >= len(s.subscribes) {
+		return nil
+	}
+	return append([]string(nil), s.subscribes[conn]...)
+}
+
+func (s *fakeStreamServer) connCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+func (s *fakeStreamServer) pongCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pongs
+}
+
+func (s *fakeStreamServer) waitConn(t *testing.T) *websocket.Conn {
+	t.Helper()
+	select {
+	case conn := <-s.connected:
+		return conn
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for stream connection")
+		return nil
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func runStream(t *testing.T, stream *Stream, handler func(string, json.RawMessage)) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stream.Run(ctx, handler)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func klinePayload(stream string, closed bool) []byte {
+	return []byte(fmt.Sprintf(`{"stream":%q,"data":{"e":"kline","E":1700000060001,"s":"BTCUSDT","k":{"t":1700000000000,"T":1700000059999,"s":"BTCUSDT","i":"1m","f":100,"L":200,"o":"100.0","c":"101.5","h":"102.0","l":"99.5","v":"12.5","n":100,"x":%t,"q":"1260.0","V":"6.0","Q":"605.0","B":"0"}}}`, stream, closed))
+}
+
+func TestStreamRestoresSubscriptionsAfterReconnect(t *testing.T) {
+	server := newFakeStreamServer(t, nil)
+
+	stream := NewStream(server.wsURL())
+	stream.ReconnectDelay = 10 * time.Millisecond
+	if err := stream.Subscribe("btcusdt@kline_1m"); err != nil {
+		t.Fatalf("Subscribe before connect: %v", err)
+	}
+
+	runStream(t, stream, func(string, json.RawMessage) {})
+
+	first := server.waitConn(t)
+	waitFor(t, "initial subscribe", func() bool { return len(server.subscribed(0)) == 1 })
+
+	// 연결 중 추가 구독은 바로 전송
+	if err := stream.Subscribe("ethusdt@kline_1m"); err != nil {
+		t.Fatalf("Subscribe while connected: %v", err)
+	}
+	waitFor(t, "live subscribe", func() bool { return len(server.subscribed(0)) == 2 })
+
+	// 서버가 연결을 끊으면 다시 연결해 전체 구독을 한 번에 복구
+	first.Close()
+	server.waitConn(t)
+	waitFor(t, "restored subscribe", func() bool { return len(server.subscribed(1)) == 2 })
+
+	want := []string{"btcusdt@kline_1m", "ethusdt@kline_1m"}
+	if got := server.subscribed(1); !reflect.DeepEqual(got, want) {
+		t.Errorf("restored subscriptions = %v, want %v", got, want)
+	}
+	if got := stream.Streams(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Streams() = %v, want %v", got, want)
+	}
+}
+
+func TestStreamPingPongKeepsConnectionAlive(t *testing.T) {
+	server := newFakeStreamServer(t, func(conn *websocket.Conn) {
+		// 서버도 ping을 보냄, 클라이언트는 pong으로 응답해야 함
+		for i := 0; i < 5; i++ {
+			time.Sleep(40 * time.Millisecond)
+			if err := conn.WriteControl(websocket.PingMessage, []byte("ping"), time.Now().Add(time.Second)); err != nil {
+				return
+			}
+		}
+	})
+
+	stream := NewStream(server.wsURL())
+	// read deadline은 3*PingInterval(90ms), 데이터 메시지 없이 그보다 오래 유지되어야 함
+	stream.PingInterval = 30 * time.Millisecond
+	stream.ReconnectDelay = 10 * time.Millisecond
+
+	runStream(t, stream, func(string, json.RawMessage) {})
+	server.waitConn(t)
+
+	time.Sleep(400 * time.Millisecond)
+
+	if got := server.connCount(); got != 1 {
+		t.Errorf("connections = %d, want 1 (read deadline expired without data messages)", got)
+	}
+	if server.pongCount() == 0 {
+		t.Error("client did not answer server pings with pongs")
+	}
+}
+
+func TestStreamReconnectsWhenPongsStop(t *testing.T) {
+	server := newFakeStreamServer(t, nil)
+	// 서버가 클라이언트 ping에 응답하지 않으면 read deadline이 지나 다시 연결해야 함
+	server.noPong = true
+
+	stream := NewStream(server.wsURL())
+	stream.PingInterval = 20 * time.Millisecond
+	stream.ReconnectDelay = 10 * time.Millisecond
+
+	runStream(t, stream, func(string, json.RawMessage) {})
+	waitFor(t, "reconnect after missing pongs", func() bool { return server.connCount() >= 2 })
+}
+
+func TestParseKlineEventTradeIDsAndLow(t *testing.T) {
+	var message streamMessage
+	if err := json.Unmarshal(klinePayload("btcusdt@kline_1m", true), &message); err != nil {
+		t.Fatalf("decoding fixture: %v", err)
+	}
+
+	event, err := ParseKlineEvent(message.Data)
+	if err != nil {
+		t.Fatalf("ParseKlineEvent: %v", err)
+	}
+
+	// "L"(마지막 체결 id 200)이 "l"(저가)로 들어가면 안 됨
+	if event.Candle.Low != 99.5 {
+		t.Errorf("Low = %v, want 99.5", event.Candle.Low)
+	}
+	if event.Candle.High != 102.0 {
+		t.Errorf("High = %v, want 102", event.Candle.High)
+	}
+	if event.Candle.OpenTime != 1700000000000 || event.Candle.CloseTime != 1700000059999 {
+		t.Errorf("times = %d..%d", event.Candle.OpenTime, event.Candle.CloseTime)
+	}
+	if event.Symbol != "BTCUSDT" || event.Interval != "1m" || !event.Closed {
+		t.Errorf("event = %+v", event)
+	}
+}
+
+func TestRunKlinesClosedFlag(t *testing.T) {
+	server := newFakeStreamServer(t, func(conn *websocket.Conn) {
+		for _, closed := range []bool{false, false, true} {
+			if err := conn.WriteMessage(websocket.TextMessage, klinePayload("btcusdt@kline_1m", closed)); err != nil {
+				return
+			}
+		}
+		// 캔들 스트림이 아닌 메시지는 전달하지 않음
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@depth","data":{}}`))
+		conn.WriteMessage(websocket.TextMessage, klinePayload("ethusdt@kline_1m", false))
+	})
+
+	stream := NewStream(server.wsURL())
+	stream.ReconnectDelay = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan KlineEvent, 16)
+	go stream.RunKlines(ctx, events)
+
+	var got []bool
+	for len(got) < 4 {
+		select {
+		case event := <-events:
+			got = append(got, event.Closed)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out, got %v", got)
+		}
+	}
+
+	want := []bool{false, false, true, false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("closed flags = %v, want %v", got, want)
+	}
+
+	closed := 0
+	for _, flag := range got {
+		if flag {
+			closed++
+		}
+	}
+	if closed != 1 {
+		t.Errorf("closed candles = %d, want only the x:true one", closed)
+	}
+}
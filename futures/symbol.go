@@ -1,80 +1,230 @@
 package futures
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// 거래 가능한 심볼 상태 (그 외 PENDING_TRADING, SETTLING, CLOSE 등)
+	SYMBOL_STATUS_TRADING = "TRADING"
+
+	CONTRACT_TYPE_PERPETUAL = "PERPETUAL"
 )
 
+// PRICE_FILTER: 가격 범위와 호가 단위
+type PriceFilter struct {
+	MinPrice decimal.Decimal
+	MaxPrice decimal.Decimal
+	TickSize decimal.Decimal
+}
+
+// LOT_SIZE, MARKET_LOT_SIZE: 수량 범위와 수량 단위
+type LotSizeFilter struct {
+	MinQty   decimal.Decimal
+	MaxQty   decimal.Decimal
+	StepSize decimal.Decimal
+}
+
+// PERCENT_PRICE: 마크 가격 대비 주문 가격 허용 범위 (배수)
+type PercentPriceFilter struct {
+	MultiplierUp      decimal.Decimal
+	MultiplierDown    decimal.Decimal
+	MultiplierDecimal int
+}
+
 type SymbolInfo struct {
-	Symbol              string `json:"symbol"`
-	PricePrecision      int    `json:"pricePrecision"`
-	QuantityPrecision   int    `json:"quantityPrecision"`
-	BaseAssetPrecision  int    `json:"baseAssetPrecision"`
-	QuoteAssetPrecision int    `json:"quotePrecision"`
-	MinNotional         float64
-	StepSize            float64
+	Symbol              string
+	Pair                string
+	ContractType        string
+	Status              string
+	BaseAsset           string
+	QuoteAsset          string
+	MarginAsset         string
+	PricePrecision      int
+	QuantityPrecision   int
+	BaseAssetPrecision  int
+	QuoteAssetPrecision int
+	// 상장 시각, 만기 시각 (ms, 무기한 계약은 먼 미래)
+	OnboardDate  int64
+	DeliveryDate int64
+	OrderTypes   []string
+	TimeInForce  []string
+
+	PriceFilter   PriceFilter
+	LotSize       LotSizeFilter
+	MarketLotSize LotSizeFilter
+	PercentPrice  PercentPriceFilter
+	// 심볼당 최대 미체결 주문 수, 최대 조건부(STOP/TP 등) 주문 수
+	MaxNumOrders     int
+	MaxNumAlgoOrders int
+
+	MinNotional decimal.Decimal
+	// LotSize.StepSize와 같음
+	StepSize decimal.Decimal
 }
 
-// 심볼 정보 조회
-func (f *FutureClient) GetSymbolInfo(symbol string) (*SymbolInfo, error) {
-	endpoint := "/fapi/v1/exchangeInfo"
+func (s *SymbolInfo) IsTrading() bool {
+	return s.Status == SYMBOL_STATUS_TRADING
+}
 
-	req, err := http.NewRequest("GET", f.BaseURL+endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
+type rawSymbolInfo struct {
+	Symbol              string            `json:"symbol"`
+	Pair                string            `json:"pair"`
+	ContractType        string            `json:"contractType"`
+	Status              string            `json:"status"`
+	BaseAsset           string            `json:"baseAsset"`
+	QuoteAsset          string            `json:"quoteAsset"`
+	MarginAsset         string            `json:"marginAsset"`
+	PricePrecision      int               `json:"pricePrecision"`
+	QuantityPrecision   int               `json:"quantityPrecision"`
+	BaseAssetPrecision  int               `json:"baseAssetPrecision"`
+	QuoteAssetPrecision int               `json:"quotePrecision"`
+	OnboardDate         int64             `json:"onboardDate"`
+	DeliveryDate        int64             `json:"deliveryDate"`
+	OrderTypes          []string          `json:"orderTypes"`
+	TimeInForce         []string          `json:"timeInForce"`
+	Filters             []rawSymbolFilter `json:"filters"`
+}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("sending request: %w", err)
+// 필터 종류마다 필드가 달라 필요한 필드를 모두 둠
+type rawSymbolFilter struct {
+	FilterType        string      `json:"filterType"`
+	MinPrice          string      `json:"minPrice"`
+	MaxPrice          string      `json:"maxPrice"`
+	TickSize          string      `json:"tickSize"`
+	MinQty            string      `json:"minQty"`
+	MaxQty            string      `json:"maxQty"`
+	StepSize          string      `json:"stepSize"`
+	Notional          string      `json:"notional"`
+	Limit             int         `json:"limit"`
+	MultiplierUp      string      `json:"multiplierUp"`
+	MultiplierDown    string      `json:"multiplierDown"`
+	MultiplierDecimal json.Number `json:"multiplierDecimal"`
+}
+
+func (r rawSymbolInfo) symbolInfo() (*SymbolInfo, error) {
+	info := &SymbolInfo{
+		Symbol:              r.Symbol,
+		Pair:                r.Pair,
+		ContractType:        r.ContractType,
+		Status:              r.Status,
+		BaseAsset:           r.BaseAsset,
+		QuoteAsset:          r.QuoteAsset,
+		MarginAsset:         r.MarginAsset,
+		PricePrecision:      r.PricePrecision,
+		QuantityPrecision:   r.QuantityPrecision,
+		BaseAssetPrecision:  r.BaseAssetPrecision,
+		QuoteAssetPrecision: r.QuoteAssetPrecision,
+		OnboardDate:         r.OnboardDate,
+		DeliveryDate:        r.DeliveryDate,
+		OrderTypes:          r.OrderTypes,
+		TimeInForce:         r.TimeInForce,
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+	for _, filter := range r.Filters {
+		var err error
+		switch filter.FilterType {
+		case "PRICE_FILTER":
+			info.PriceFilter, err = parsePriceFilter(filter)
+		case "LOT_SIZE":
+			info.LotSize, err = parseLotSizeFilter(filter)
+			info.StepSize = info.LotSize.StepSize
+		case "MARKET_LOT_SIZE":
+			info.MarketLotSize, err = parseLotSizeFilter(filter)
+		case "MIN_NOTIONAL":
+			info.MinNotional, err = decimal.NewFromString(filter.Notional)
+		case "MAX_NUM_ORDERS":
+			info.MaxNumOrders = filter.Limit
+		case "MAX_NUM_ALGO_ORDERS":
+			info.MaxNumAlgoOrders = filter.Limit
+		case "PERCENT_PRICE":
+			info.PercentPrice, err = parsePercentPriceFilter(filter)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s %s: %w", r.Symbol, filter.FilterType, err)
+		}
 	}
 
-	var result struct {
-		Symbols []struct {
-			Symbol            string `json:"symbol"`
-			PricePrecision    int    `json:"pricePrecision"`
-			QuantityPrecision int    `json:"quantityPrecision"`
-			Filters           []struct {
-				FilterType  string `json:"filterType"`
-				MinNotional string `json:"notional,omitempty"`
-				StepSize    string `json:"stepSize,omitempty"`
-			} `json:"filters"`
-		} `json:"symbols"`
+	return info, nil
+}
+
+func parsePriceFilter(filter rawSymbolFilter) (PriceFilter, error) {
+	var result PriceFilter
+	var err error
+	if result.MinPrice, err = decimal.NewFromString(filter.MinPrice); err != nil {
+		return result, err
+	}
+	if result.MaxPrice, err = decimal.NewFromString(filter.MaxPrice); err != nil {
+		return result, err
 	}
+	if result.TickSize, err = decimal.NewFromString(filter.TickSize); err != nil {
+		return result, err
+	}
+	return result, nil
+}
 
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
+func parseLotSizeFilter(filter rawSymbolFilter) (LotSizeFilter, error) {
+	var result LotSizeFilter
+	var err error
+	if result.MinQty, err = decimal.NewFromString(filter.MinQty); err != nil {
+		return result, err
+	}
+	if result.MaxQty, err = decimal.NewFromString(filter.MaxQty); err != nil {
+		return result, err
 	}
+	if result.StepSize, err = decimal.NewFromString(filter.StepSize); err != nil {
+		return result, err
+	}
+	return result, nil
+}
 
-	for _, s := range result.Symbols {
-		if s.Symbol == symbol {
-			info := &SymbolInfo{
-				Symbol:            s.Symbol,
-				PricePrecision:    s.PricePrecision,
-				QuantityPrecision: s.QuantityPrecision,
-			}
-
-			// Parse filters
-			for _, filter := range s.Filters {
-				switch filter.FilterType {
-				case "MIN_NOTIONAL":
-					info.MinNotional, _ = strconv.ParseFloat(filter.MinNotional, 64)
-				case "LOT_SIZE":
-					info.StepSize, _ = strconv.ParseFloat(filter.StepSize, 64)
-				}
-			}
-
-			return info, nil
+func parsePercentPriceFilter(filter rawSymbolFilter) (PercentPriceFilter, error) {
+	var result PercentPriceFilter
+	var err error
+	if result.MultiplierUp, err = decimal.NewFromString(filter.MultiplierUp); err != nil {
+		return result, err
+	}
+	if result.MultiplierDown, err = decimal.NewFromString(filter.MultiplierDown); err != nil {
+		return result, err
+	}
+	if filter.MultiplierDecimal != "" {
+		places, err := filter.MultiplierDecimal.Int64()
+		if err != nil {
+			return result, err
 		}
+		result.MultiplierDecimal = int(places)
+	}
+	return result, nil
+}
+
+// 심볼 정보 조회 (캐시된 exchangeInfo 사용)
+func (f *FutureClient) GetSymbolInfo(symbol string) (*SymbolInfo, error) {
+	return f.GetSymbolInfoContext(context.Background(), symbol)
+}
+
+func (f *FutureClient) GetSymbolInfoContext(ctx context.Context, symbol string) (*SymbolInfo, error) {
+	info, err := f.GetExchangeInfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if symbolInfo, ok := info.Symbols[symbol]; ok {
+		result := *symbolInfo
+		return &result, nil
+	}
+
+	// 캐시 이후 새로 상장됐을 수 있으므로 갱신, 단 최근에 받은 정보면 다시 받지 않음
+	info, err = f.refreshExchangeInfoOlderThan(ctx, exchangeInfoMinRefreshInterval)
+	if err != nil {
+		return nil, err
+	}
+	if symbolInfo, ok := info.Symbols[symbol]; ok {
+		result := *symbolInfo
+		return &result, nil
 	}
 
 	return nil, fmt.Errorf("symbol not found: %s", symbol)
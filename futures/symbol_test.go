@@ -0,0 +1,70 @@
+package futures
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newExchangeInfoServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/exchangeInfo" {
+			http.NotFound(w, r)
+			return
+		}
+		fetches.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"serverTime":1700000000000,"symbols":[{"symbol":"BTCUSDT","status":"TRADING","filters":[{"filterType":"LOT_SIZE","minQty":"0.001","maxQty":"1000","stepSize":"0.001"}]}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &fetches
+}
+
+func TestGetSymbolInfoUnknownSymbolRefreshesAtMostOncePerInterval(t *testing.T) {
+	server, fetches := newExchangeInfoServer(t)
+	client := NewClient("", "")
+	client.BaseURL = server.URL
+
+	info, err := client.GetSymbolInfo("BTCUSDT")
+	if err != nil {
+		t.Fatalf("GetSymbolInfo(BTCUSDT): %v", err)
+	}
+	if info.StepSize.String() != "0.001" {
+		t.Errorf("StepSize = %s, want 0.001", info.StepSize)
+	}
+
+	// 방금 받은 정보에 없는 심볼은 다시 받지 않음
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetSymbolInfo("NOPEUSDT"); err == nil {
+			t.Fatal("GetSymbolInfo(NOPEUSDT) succeeded")
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("exchangeInfo fetched %d times, want 1", got)
+	}
+
+	// 최소 간격이 지나면 한 번 갱신
+	cache := client.exchangeInfoCache()
+	cache.mu.Lock()
+	cache.info.FetchedAt = time.Now().Add(-exchangeInfoMinRefreshInterval - time.Second)
+	cache.mu.Unlock()
+
+	for i := 0; i < 3; i++ {
+		client.GetSymbolInfo("NOPEUSDT")
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Errorf("exchangeInfo fetched %d times, want 2", got)
+	}
+
+	// RefreshExchangeInfo는 간격과 관계없이 다시 받음
+	if _, err := client.RefreshExchangeInfo(); err != nil {
+		t.Fatalf("RefreshExchangeInfo: %v", err)
+	}
+	if got := fetches.Load(); got != 3 {
+		t.Errorf("exchangeInfo fetched %d times, want 3", got)
+	}
+}
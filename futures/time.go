@@ -1,23 +1,26 @@
 package futures
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"time"
 )
 
 func (f *FutureClient) GetServerTime() (int64, error) {
-	resp, err := http.Get(f.BaseURL + "/fapi/v1/time")
+	return f.GetServerTimeContext(context.Background())
+}
+
+func (f *FutureClient) GetServerTimeContext(ctx context.Context) (int64, error) {
+	body, err := f.doRequest(ctx, "GET", "/fapi/v1/time", nil, false)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
 
 	var result struct {
 		ServerTime int64 `json:"serverTime"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(body, &result); err != nil {
 		return 0, err
 	}
 
@@ -26,20 +29,20 @@ func (f *FutureClient) GetServerTime() (int64, error) {
 
 // 서버 시간 동기화 함수
 func (f *FutureClient) SyncServerTime() error {
-	resp, err := http.Get(f.BaseURL + "/fapi/v1/time")
+	return f.SyncServerTimeContext(context.Background())
+}
+
+func (f *FutureClient) SyncServerTimeContext(ctx context.Context) error {
+	requestedAt := time.Now().UnixMilli()
+	serverTime, err := f.GetServerTimeContext(ctx)
 	if err != nil {
 		return fmt.Errorf("getting server time: %w", err)
 	}
-	defer resp.Body.Close()
+	receivedAt := time.Now().UnixMilli()
 
-	var result struct {
-		ServerTime int64 `json:"serverTime"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("decoding response: %w", err)
-	}
-
-	f.ServerTimeOffset = result.ServerTime - time.Now().UnixMilli()
+	// 왕복 시간의 절반만큼 지연됐다고 보고 오프셋 계산
+	f.ServerTimeOffset = serverTime - (requestedAt+receivedAt)/2
+	f.timeSynced = true
 	return nil
 }
 
@@ -47,3 +50,8 @@ func (f *FutureClient) SyncServerTime() error {
 func (f *FutureClient) GetTimestamp() int64 {
 	return time.Now().UnixMilli() + f.ServerTimeOffset
 }
+
+// 서버 시간 오프셋을 적용한 현재 시각
+func (f *FutureClient) ServerTime() time.Time {
+	return time.UnixMilli(f.GetTimestamp())
+}
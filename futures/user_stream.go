@@ -0,0 +1,300 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	EVENT_ORDER_TRADE_UPDATE = "ORDER_TRADE_UPDATE"
+	EVENT_ACCOUNT_UPDATE     = "ACCOUNT_UPDATE"
+	EVENT_MARGIN_CALL        = "MARGIN_CALL"
+	EVENT_LISTEN_KEY_EXPIRED = "listenKeyExpired"
+
+	// listenKey는 60분 동안 keepalive가 없으면 만료
+	defaultListenKeyKeepAlive = 30 * time.Minute
+)
+
+// 주문 상태 변경 (체결, 취소, 만료, TP/SL 발동 등)
+type OrderUpdateEvent struct {
+	Symbol          string          `json:"s"`
+	ClientOrderID   string          `json:"c"`
+	Side            OrderSide       `json:"S"`
+	Type            string          `json:"o"`
+	OrigType        string          `json:"ot"`
+	TimeInForce     string          `json:"f"`
+	OrigQty         decimal.Decimal `json:"q"`
+	Price           decimal.Decimal `json:"p"`
+	AvgPrice        decimal.Decimal `json:"ap"`
+	StopPrice       decimal.Decimal `json:"sp"`
+	ExecutionType   string          `json:"x"`
+	Status          string          `json:"X"`
+	OrderID         int64           `json:"i"`
+	LastFilledQty   decimal.Decimal `json:"l"`
+	FilledQty       decimal.Decimal `json:"z"`
+	LastFilledPrice decimal.Decimal `json:"L"`
+	CommissionAsset string          `json:"N"`
+	Commission      decimal.Decimal `json:"n"`
+	TradeTime       int64           `json:"T"`
+	TradeID         int64           `json:"t"`
+	IsMaker         bool            `json:"m"`
+	ReduceOnly      bool            `json:"R"`
+	WorkingType     string          `json:"wt"`
+	PositionSide    PositionSide    `json:"ps"`
+	ClosePosition   bool            `json:"cp"`
+	RealizedProfit  decimal.Decimal `json:"rp"`
+}
+
+type BalanceUpdate struct {
+	Asset              string          `json:"a"`
+	WalletBalance      decimal.Decimal `json:"wb"`
+	CrossWalletBalance decimal.Decimal `json:"cw"`
+	BalanceChange      decimal.Decimal `json:"bc"`
+}
+
+type PositionUpdate struct {
+	Symbol              string          `json:"s"`
+	PositionAmt         decimal.Decimal `json:"pa"`
+	EntryPrice          decimal.Decimal `json:"ep"`
+	AccumulatedRealized decimal.Decimal `json:"cr"`
+	UnRealizedProfit    decimal.Decimal `json:"up"`
+	MarginType          string          `json:"mt"`
+	IsolatedWallet      decimal.Decimal `json:"iw"`
+	PositionSide        PositionSide    `json:"ps"`
+}
+
+// 잔고, 포지션 변경 (Reason: ORDER, FUNDING_FEE, DEPOSIT 등)
+type AccountUpdateEvent struct {
+	Reason    string           `json:"m"`
+	Balances  []BalanceUpdate  `json:"B"`
+	Positions []PositionUpdate `json:"P"`
+}
+
+type MarginCallPosition struct {
+	Symbol            string          `json:"s"`
+	PositionSide      PositionSide    `json:"ps"`
+	PositionAmt       decimal.Decimal `json:"pa"`
+	MarginType        string          `json:"mt"`
+	IsolatedWallet    decimal.Decimal `json:"iw"`
+	MarkPrice         decimal.Decimal `json:"mp"`
+	UnRealizedProfit  decimal.Decimal `json:"up"`
+	MaintenanceMargin decimal.Decimal `json:"mm"`
+}
+
+type MarginCallEvent struct {
+	CrossWalletBalance decimal.Decimal      `json:"cw"`
+	Positions          []MarginCallPosition `json:"p"`
+}
+
+// 사용자 데이터 스트림 이벤트, Type에 맞는 필드만 채워짐
+type UserDataEvent struct {
+	Type            string
+	EventTime       int64
+	TransactionTime int64
+
+	OrderUpdate   *OrderUpdateEvent
+	AccountUpdate *AccountUpdateEvent
+	MarginCall    *MarginCallEvent
+}
+
+func ParseUserDataEvent(data []byte) (UserDataEvent, error) {
+	var header struct {
+		Type            string `json:"e"`
+		EventTime       int64  `json:"E"`
+		TransactionTime int64  `json:"T"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return UserDataEvent{}, fmt.Errorf("parsing user data event: %w", err)
+	}
+
+	event := UserDataEvent{
+		Type:            header.Type,
+		EventTime:       header.EventTime,
+		TransactionTime: header.TransactionTime,
+	}
+
+	switch header.Type {
+	case EVENT_ORDER_TRADE_UPDATE:
+		var payload struct {
+			Order OrderUpdateEvent `json:"o"`
+		}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return event, fmt.Errorf("parsing %s: %w", header.Type, err)
+		}
+		event.OrderUpdate = &payload.Order
+
+	case EVENT_ACCOUNT_UPDATE:
+		var payload struct {
+			Account AccountUpdateEvent `json:"a"`
+		}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return event, fmt.Errorf("parsing %s: %w", header.Type, err)
+		}
+		event.AccountUpdate = &payload.Account
+
+	case EVENT_MARGIN_CALL:
+		var payload MarginCallEvent
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return event, fmt.Errorf("parsing %s: %w", header.Type, err)
+		}
+		event.MarginCall = &payload
+	}
+
+	return event, nil
+}
+
+// listenKey 생성 (이미 있으면 같은 key를 돌려주고 유효 시간만 연장됨)
+func (f *FutureClient) CreateListenKey() (string, error) {
+	return f.CreateListenKeyContext(context.Background())
+}
+
+func (f *FutureClient) CreateListenKeyContext(ctx context.Context) (string, error) {
+	body, err := f.doRequest(ctx, "POST", "/fapi/v1/listenKey", nil, false)
+	if err != nil {
+		return "", fmt.Errorf("creating listen key: %w", err)
+	}
+
+	var result struct {
+		ListenKey string `json:"listenKey"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("parsing response: %w", err)
+	}
+
+	return result.ListenKey, nil
+}
+
+// listenKey 유효 시간 연장 (60분)
+func (f *FutureClient) KeepAliveListenKey() error {
+	return f.KeepAliveListenKeyContext(context.Background())
+}
+
+func (f *FutureClient) KeepAliveListenKeyContext(ctx context.Context) error {
+	if _, err := f.doRequest(ctx, "PUT", "/fapi/v1/listenKey", nil, false); err != nil {
+		return fmt.Errorf("keeping alive listen key: %w", err)
+	}
+	return nil
+}
+
+func (f *FutureClient) CloseListenKey() error {
+	return f.CloseListenKeyContext(context.Background())
+}
+
+func (f *FutureClient) CloseListenKeyContext(ctx context.Context) error {
+	if _, err := f.doRequest(ctx, "DELETE", "/fapi/v1/listenKey", nil, false); err != nil {
+		return fmt.Errorf("closing listen key: %w", err)
+	}
+	return nil
+}
+
+// 사용자 데이터 스트림 (체결, 잔고/포지션 변경, 마진콜)
+// listenKey 생성, keepalive, 만료 시 재발급을 처리
+type UserDataStream struct {
+	KeepAliveInterval time.Duration
+
+	client *FutureClient
+	stream *Stream
+
+	mu        sync.Mutex
+	listenKey string
+}
+
+func (f *FutureClient) NewUserDataStream() *UserDataStream {
+	return &UserDataStream{
+		KeepAliveInterval: defaultListenKeyKeepAlive,
+		client:            f,
+		stream:            f.NewStream(),
+	}
+}
+
+// ctx가 취소될 때까지 이벤트를 events로 전달, 종료 시 listenKey 닫음
+func (u *UserDataStream) Run(ctx context.Context, events chan<- UserDataEvent) error {
+	if err := u.renewListenKey(ctx); err != nil {
+		return err
+	}
+
+	defer func() {
+		// ctx는 이미 취소됐을 수 있어 별도 ctx로 정리
+		closeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := u.client.CloseListenKeyContext(closeCtx); err != nil {
+			log.Printf("user data stream: %v", err)
+		}
+	}()
+
+	go u.keepAlive(ctx)
+
+	return u.stream.Run(ctx, func(stream string, data json.RawMessage) {
+		event, err := ParseUserDataEvent(data)
+		if err != nil {
+			log.Printf("user data stream: %v", err)
+			return
+		}
+
+		if event.Type == EVENT_LISTEN_KEY_EXPIRED {
+			log.Printf("user data stream: listen key expired, renewing")
+			if err := u.renewListenKey(ctx); err != nil {
+				log.Printf("user data stream: %v", err)
+			}
+			return
+		}
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	})
+}
+
+func (u *UserDataStream) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(u.KeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := u.client.KeepAliveListenKeyContext(ctx)
+			if err == nil {
+				continue
+			}
+
+			log.Printf("user data stream: %v", err)
+			if binanceErrorCode(err) == ERROR_INVALID_LISTEN_KEY {
+				if err := u.renewListenKey(ctx); err != nil {
+					log.Printf("user data stream: %v", err)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// 새 listenKey를 받아 구독 교체
+func (u *UserDataStream) renewListenKey(ctx context.Context) error {
+	listenKey, err := u.client.CreateListenKeyContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	previous := u.listenKey
+	u.listenKey = listenKey
+	u.mu.Unlock()
+
+	if previous == listenKey {
+		return nil
+	}
+	if previous != "" {
+		if err := u.stream.Unsubscribe(previous); err != nil {
+			return err
+		}
+	}
+	return u.stream.Subscribe(listenKey)
+}
@@ -5,5 +5,7 @@ go 1.22.5
 require (
 	github.com/assist-by/abmodule v0.9.1
 	github.com/assist-by/libStruct v0.9.8
+	github.com/gorilla/websocket v1.5.3
 	github.com/joho/godotenv v1.5.1
+	github.com/shopspring/decimal v1.4.0
 )
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/assist-by/mono-buy/dataset"
+)
+
+// import 모드: data.binance.vision에서 받은 캔들 아카이브(zip)를 데이터셋으로 변환
+// 예) mono-buy import -src ~/Downloads/binance -symbols BTCUSDT -interval 15m
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	src := flags.String("src", "", "아카이브(zip)가 있는 디렉터리 (하위 디렉터리 포함)")
+	dir := flags.String("dir", "data", "데이터셋 루트 디렉터리")
+	symbolsFlag := flags.String("symbols", "", "가져올 심볼 (쉼표로 구분, 기본값: 전체)")
+	interval := flags.String("interval", "", "가져올 캔들 간격 (기본값: 전체)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if *src == "" {
+		return fmt.Errorf("-src is required")
+	}
+
+	symbols := make(map[string]bool)
+	for _, symbol := range parseSymbols(*symbolsFlag) {
+		symbols[symbol] = true
+	}
+	accept := func(archive dataset.Archive) bool {
+		if len(symbols) > 0 && !symbols[archive.Symbol] {
+			return false
+		}
+		return *interval == "" || archive.Interval == *interval
+	}
+
+	results, err := dataset.Import(*src, *dir, accept)
+	for _, result := range results {
+		month := result.Month.Format("2006-01")
+		if result.Skipped {
+			log.Printf("⏭️ %s %s %s already imported", result.Symbol, result.Interval, month)
+			continue
+		}
+		log.Printf("💾 %s %s %s: %d candles from %d archives (%d checksum verified) -> %s",
+			result.Symbol, result.Interval, month, result.Candles, result.Archives, result.Verified, result.Path)
+	}
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		log.Printf("⚠️ No kline archives found in %s", *src)
+		return nil
+	}
+
+	log.Printf("✅ Import finished: %s", *dir)
+	return nil
+}
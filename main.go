@@ -2,16 +2,19 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
-	"strconv"
 	"sync"
+	"syscall"
 	"time"
 
 	lib "github.com/assist-by/libStruct"
+	"github.com/assist-by/mono-buy/discord"
 	"github.com/assist-by/mono-buy/futures"
 	"github.com/joho/godotenv"
+	"github.com/shopspring/decimal"
 )
 
 const (
@@ -20,6 +23,11 @@ const (
 	maxRetries      = 5
 	retryDelay      = 5 * time.Second
 	candleLimit     = 1000
+	// 캔들 마감 후 기다리는 기본 시간 (CANDLE_CLOSE_GRACE)
+	defaultCandleCloseGrace = 5 * time.Second
+	// REST에 마감 캔들이 아직 없으면 잠시 후 다시 확인
+	candleConfirmRetries    = 3
+	candleConfirmRetryDelay = 2 * time.Second
 	// fetchInterval   = 1 * time.Minute
 )
 
@@ -30,12 +38,16 @@ var (
 	discordWebhookURL      string
 	discordWebhookTradeURL string
 	fetchInterval          time.Duration
+	candleCloseGrace       time.Duration
+	binanceEnv             futures.Environment
+	marketStatsPeriod      futures.StatsPeriod
 	runningMutex           sync.Mutex
 	serviceCtx             context.Context
 	serviceCtxCancel       context.CancelFunc
 )
 
-func init() {
+// 매매 서비스 설정 로드 (download, import 모드에서는 사용하지 않음)
+func loadConfig() {
 	err := godotenv.Load()
 	if err != nil {
 		log.Fatal("Error loading .env file")
@@ -49,9 +61,45 @@ func init() {
 	if err != nil {
 		log.Fatalf("Invalid fetch interval: %v", err)
 	}
+	// 캔들 마감 후 스트림 이벤트를 기다리는 시간, 이후에도 평가되지 않았으면 REST로 평가
+	candleCloseGrace = defaultCandleCloseGrace
+	if grace := os.Getenv("CANDLE_CLOSE_GRACE"); grace != "" {
+		candleCloseGrace, err = time.ParseDuration(grace)
+		if err != nil || candleCloseGrace < 0 {
+			log.Fatalf("Invalid candle close grace: %s", grace)
+		}
+	}
+	binanceEnv, err = futures.EnvironmentByName(
+		os.Getenv("BINANCE_ENV"),
+		os.Getenv("BINANCE_REST_URL"),
+		os.Getenv("BINANCE_WS_URL"),
+	)
+	if err != nil {
+		log.Fatalf("Invalid binance environment: %v", err)
+	}
+	marketStatsPeriod = futures.PERIOD_5M
+	if period := os.Getenv("MARKET_STATS_PERIOD"); period != "" {
+		marketStatsPeriod = futures.StatsPeriod(period)
+	}
+	if !marketStatsPeriod.Valid() {
+		log.Fatalf("Invalid market stats period: %s", marketStatsPeriod)
+	}
+	if err := loadCandleCheckConfig(); err != nil {
+		log.Fatalf("Invalid candle check config: %v", err)
+	}
 	serviceCtx, serviceCtxCancel = context.WithCancel(context.Background())
 }
 
+// 설정된 환경(mainnet/testnet/custom)으로 접속하는 선물 클라이언트
+func newFuturesClient() *futures.FutureClient {
+	return futures.NewClient(apikey, secretkey, futures.WithEnvironment(binanceEnv))
+}
+
+// 알림 footer에 접속 환경을 표시하는 discord 클라이언트
+func newDiscordClient(webhookURL string) *discord.Client {
+	return discord.NewClient(webhookURL).SetEnvironment(binanceEnv.Label())
+}
+
 func NewCoinTracker(symbol string) *lib.CoinTracker {
 	return &lib.CoinTracker{
 		Symbol: symbol,
@@ -61,129 +109,112 @@ func NewCoinTracker(symbol string) *lib.CoinTracker {
 func startService(ctx context.Context) {
 
 	signals := make(chan os.Signal, 1)
-	signal.Notify(signals, os.Interrupt)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
 
-	client := futures.NewClient(apikey, secretkey)
+	// 종료 시그널이 오면 진행 중인 API 요청까지 취소
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-signals:
+			log.Println("Interrupt received, shutting down...")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	client := newFuturesClient()
+	interval := getIntervalString(fetchInterval)
 	trackers := make(map[string]*lib.CoinTracker)
+	// 처음에만 전체 캔들을 받고 이후에는 새로 마감된 캔들만 추가
+	candleStore := client.NewCandleStore(candleLimit)
+	checker, err := newCandleChecker(interval)
+	if err != nil {
+		log.Printf("❌ Error creating candle checker: %v\n", err)
+		return
+	}
 
-	for {
-		now := time.Now()
-		nextCheck := now.Truncate(fetchInterval).Add(fetchInterval)
-		sleepDuration := nextCheck.Sub(now)
+	// 마감된 캔들(x:true) 이벤트가 오면 바로 시그널 평가
+	stream := client.NewStream()
+	klineEvents := make(chan futures.KlineEvent, 64)
+	go func() {
+		if err := stream.RunKlines(ctx, klineEvents); err != nil && ctx.Err() == nil {
+			log.Printf("❌ Kline stream stopped: %v\n", err)
+		}
+	}()
+
+	// 시작 시 한 번만 잔고를 조회하고 이후에는 ACCOUNT_UPDATE 이벤트로 갱신
+	walletBalances := make(map[string]decimal.Decimal)
+	balances, err := client.GetWalletBalanceContext(ctx)
+	if err != nil {
+		log.Printf("❌ Error fetching wallet balances: %v\n", err)
+	}
+	for asset, balance := range balances {
+		if total := balance.Free.Add(balance.Locked); !total.IsZero() {
+			walletBalances[asset] = total
+		}
+	}
+	userEvents := startUserDataStream(ctx, client)
+
+	refreshTrackers(ctx, client, stream, candleStore, trackers, walletBalances, interval)
 
-		log.Printf("Waiting for %v until next fetch at %v\n", sleepDuration.Round(time.Second), nextCheck.Format("2006-01-02 15:04:05"))
+	// 주기마다 상위 코인을 갱신하고, 스트림으로 평가되지 않은 심볼은 REST로 평가
+	// 로컬 시계가 아니라 서버 시간 기준 캔들 마감 시각에 맞춰 깨어남
+	if err := client.SyncServerTimeContext(ctx); err != nil {
+		log.Printf("❌ Error syncing server time: %v\n", err)
+	}
+	nextClose, wait := nextCheck(client.ServerTime())
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
 
+	for {
 		select {
-		case <-time.After(sleepDuration):
-			topSymbols, err := client.GetTopVolumeSymbols(3)
-			if err != nil {
-				log.Printf("❌ Error fetching top volume symbols: %v\n", err)
+		case event := <-klineEvents:
+			if !event.Closed {
 				continue
 			}
 
-			log.Printf("🔍 현재 추적 중인 상위 코인: %v\n", topSymbols)
-
-			balances, err := client.GetWalletBalance()
-			if err != nil {
-				log.Printf("❌ Error fetching wallet balances: %v\n", err)
-			} else {
-				log.Printf("=== 현재 지갑 상태 ===")
-				if len(balances) == 0 {
-					log.Printf("⚠️ 잔액이 있는 자산이 없습니다.")
-				} else {
-					for asset, balance := range balances {
-						log.Printf("🏦 %s (가용: %.8f, 잠금: %.8f)\n",
-							asset, balance.Free, balance.Locked)
-					}
-				}
+			tracker, exists := trackers[event.Symbol]
+			if !exists {
+				continue
 			}
-			log.Printf("-------------------------------------------")
 
-			for _, symbol := range topSymbols {
-				if _, exists := trackers[symbol]; !exists {
-					trackers[symbol] = NewCoinTracker(symbol)
-				}
+			closedCandle := event.Candle
+			evaluateSymbol(ctx, client, candleStore, checker, tracker, interval, &closedCandle)
 
-				candles, err := client.GetKlineData(
-					symbol,
-					getIntervalString(fetchInterval),
-					candleLimit,
-				)
+		case event := <-userEvents:
+			handleUserDataEvent(event, walletBalances)
 
-				if err != nil {
-					log.Printf("❌ Error fetching candle data for %s: %v\n", symbol, err)
-					continue
-				}
+		case <-timer.C:
+			// 방금 마감된 캔들의 CloseTime
+			expectedCloseTime := nextClose.UnixMilli() - 1
 
-				if len(candles) < 2 {
-					log.Printf("Insufficient data for %s: got %d candles\n", symbol, len(candles))
-					continue
-				}
+			// 시계가 밀리지 않도록 매 주기 서버 시간 재동기화
+			if err := client.SyncServerTimeContext(ctx); err != nil {
+				log.Printf("❌ Error syncing server time: %v\n", err)
+			}
 
-				// 마지막 캔들의 종료 시간 확인
-				lastCandle := candles[len(candles)-1]
-				lastCandleCloseTime := time.Unix(lastCandle.CloseTime/1000, 0)
-
-				var completedCandle futures.CandleData
-				if now.Equal(lastCandleCloseTime) || now.After(lastCandleCloseTime) {
-					// 마지막 캔들이 이미 완료됐다면
-					completedCandle = lastCandle
-				} else {
-					// 마지막 캔들이 아직 진행중이라면
-					completedCandle = candles[len(candles)-2]
-				}
+			refreshTrackers(ctx, client, stream, candleStore, trackers, walletBalances, interval)
 
-				indicators, err := calculateIndicators(candles)
-				if err != nil {
-					log.Printf("❌ Error calculating indicators for %s: %v\n", symbol, err)
+			for _, tracker := range trackers {
+				if tracker.LastSignalTime >= expectedCloseTime {
 					continue
 				}
-
-				signalType, conditions, stopLoss, takeProfit := generateSignal(candles, indicators)
-
-				if signalType != trackers[symbol].LastSignal || completedCandle.CloseTime != trackers[symbol].LastSignalTime {
-					price, err := strconv.ParseFloat(completedCandle.Close, 64)
-					if err != nil {
-						log.Printf("❌ Error converting price for %s: %v\n", symbol, err)
-						continue
-					}
-
-					signalResult := lib.SignalResult{
-						Symbol:     symbol,
-						Signal:     signalType,
-						Timestamp:  completedCandle.CloseTime,
-						Price:      price,
-						Conditions: conditions,
-						StopLoss:   stopLoss,
-						TakeProfit: takeProfit,
-					}
-					// 시그널 처리 중 에러가 발생해도 다음 심볼 처리를 위해 continue
-					if err := processSignal(signalResult); err != nil {
-						log.Printf("Error processing signal for %s: %v", symbol, err)
-
-					}
-
-					trackers[symbol].LastSignal = signalType
-					trackers[symbol].LastSignalTime = completedCandle.CloseTime
+				log.Printf("⚠️ No closed kline from stream for %s, falling back to REST\n", tracker.Symbol)
+				if err := confirmClosedCandle(ctx, candleStore, tracker.Symbol, interval, expectedCloseTime); err != nil {
+					log.Printf("❌ Skipping %s this tick: %v\n", tracker.Symbol, err)
+					continue
 				}
+				evaluateSymbol(ctx, client, candleStore, checker, tracker, interval, nil)
 			}
 
-			for symbol := range trackers {
-				found := false
-				for _, topSymbol := range topSymbols {
-					if symbol == topSymbol {
-						found = true
-						break
-					}
-				}
-				if !found {
-					delete(trackers, symbol)
-				}
+			// 타이머가 서버 시각보다 일찍 깨어났어도 같은 마감을 다시 점검하지 않음
+			serverNow := client.ServerTime()
+			if serverNow.Before(nextClose) {
+				serverNow = nextClose
 			}
-
-		case <-signals:
-			log.Println("Interrupt received, shutting down...")
-			return
+			nextClose, wait = nextCheck(serverNow)
+			timer.Reset(wait)
 
 		case <-ctx.Done():
 			log.Println("Context cancelled, shutting down...")
@@ -192,9 +223,187 @@ func startService(ctx context.Context) {
 	}
 }
 
+// 서버 시각 serverNow 기준 다음 캔들 마감 시각과, 마감 후 candleCloseGrace까지 기다릴 시간
+func nextCheck(serverNow time.Time) (time.Time, time.Duration) {
+	nextClose := serverNow.Truncate(fetchInterval).Add(fetchInterval)
+	wait := nextClose.Add(candleCloseGrace).Sub(serverNow)
+	log.Printf("Waiting for %v until next fetch at %v (server time)\n", wait.Round(time.Second), nextClose.Add(candleCloseGrace).Format("2006-01-02 15:04:05"))
+	return nextClose, wait
+}
+
+// expectedCloseTime에 마감된 캔들이 저장소에 들어올 때까지 잠시 간격을 두고 다시 확인
+// Binance가 캔들을 아직 마감하지 않았거나 REST에 반영되지 않았으면 이전 캔들로 평가하지 않도록 에러 반환
+func confirmClosedCandle(ctx context.Context, candleStore *futures.CandleStore, symbol, interval string, expectedCloseTime int64) error {
+	for attempt := 0; ; attempt++ {
+		candles, err := candleStore.Sync(ctx, symbol, interval)
+		if err != nil {
+			return err
+		}
+		if n := len(candles); n > 0 && candles[n-1].CloseTime >= expectedCloseTime {
+			return nil
+		}
+		if attempt >= candleConfirmRetries {
+			return fmt.Errorf("candle closing at %d not available after %d retries", expectedCloseTime, candleConfirmRetries)
+		}
+
+		log.Printf("⏳ Closed candle for %s not available yet, retrying in %v\n", symbol, candleConfirmRetryDelay)
+		select {
+		case <-time.After(candleConfirmRetryDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// 거래량 상위 코인으로 추적 대상과 캔들 스트림 구독을 갱신
+// 잔고는 사용자 데이터 스트림으로 갱신된 walletBalances를 출력
+func refreshTrackers(ctx context.Context, client *futures.FutureClient, stream *futures.Stream, candleStore *futures.CandleStore, trackers map[string]*lib.CoinTracker, walletBalances map[string]decimal.Decimal, interval string) {
+	topSymbols, err := client.GetTopVolumeSymbolsContext(ctx, 3)
+	if err != nil {
+		log.Printf("❌ Error fetching top volume symbols: %v\n", err)
+		return
+	}
+
+	log.Printf("🔍 현재 추적 중인 상위 코인: %v\n", topSymbols)
+
+	log.Printf("=== 현재 지갑 상태 ===")
+	if len(walletBalances) == 0 {
+		log.Printf("⚠️ 잔액이 있는 자산이 없습니다.")
+	} else {
+		for asset, balance := range walletBalances {
+			log.Printf("🏦 %s (지갑: %s)\n", asset, balance.StringFixed(8))
+		}
+	}
+	log.Printf("-------------------------------------------")
+
+	for _, symbol := range topSymbols {
+		if _, exists := trackers[symbol]; !exists {
+			trackers[symbol] = NewCoinTracker(symbol)
+		}
+	}
+
+	var removed []string
+	for symbol := range trackers {
+		found := false
+		for _, topSymbol := range topSymbols {
+			if symbol == topSymbol {
+				found = true
+				break
+			}
+		}
+		if !found {
+			delete(trackers, symbol)
+			candleStore.Remove(symbol, interval)
+			removed = append(removed, symbol)
+		}
+	}
+
+	if err := stream.SubscribeKlines(topSymbols, interval); err != nil {
+		log.Printf("❌ Error subscribing kline streams: %v\n", err)
+	}
+	if len(removed) > 0 {
+		if err := stream.UnsubscribeKlines(removed, interval); err != nil {
+			log.Printf("❌ Error unsubscribing kline streams: %v\n", err)
+		}
+	}
+}
+
+// 마감 여부를 판단할 서버 시각(ms)
+// 스트림의 마감 이벤트(x:true)는 서버가 마감을 확인한 것이므로, 시간 보정 오차로 now가 조금 이르더라도 그 캔들은 마감으로 봄
+func evaluationTime(now int64, closedCandle *futures.CandleData) int64 {
+	if closedCandle != nil && !closedCandle.IsClosed(now) {
+		return closedCandle.CloseTime + 1
+	}
+	return now
+}
+
+// 심볼의 마감된 캔들로 시그널을 평가하고 처리
+// closedCandle이 있으면(스트림 이벤트) 저장소에 추가하고, 없으면 서버 시간 기준으로 마감된 캔들까지 REST로 채움
+func evaluateSymbol(ctx context.Context, client *futures.FutureClient, candleStore *futures.CandleStore, checker *candleChecker, tracker *lib.CoinTracker, interval string, closedCandle *futures.CandleData) {
+	symbol := tracker.Symbol
+
+	var candles []futures.CandleData
+	var err error
+	if closedCandle != nil {
+		candles, err = candleStore.Add(ctx, symbol, interval, *closedCandle)
+	} else {
+		candles, err = candleStore.Sync(ctx, symbol, interval)
+	}
+	if err != nil {
+		log.Printf("❌ Error fetching candle data for %s: %v\n", symbol, err)
+		return
+	}
+
+	// 지표를 왜곡할 수 있는 빈 구간, 중복, 이상치를 설정에 따라 보정하거나 건너뜀
+	candles, ok := checker.check(symbol, candles)
+	if !ok {
+		return
+	}
+
+	// 지표, 시그널, 알림 시각과 가격 모두 서버 시간 기준으로 마감된 캔들까지만 사용
+	candles = futures.ClosedCandles(candles, evaluationTime(client.GetTimestamp(), closedCandle))
+	if len(candles) < 2 {
+		log.Printf("Insufficient data for %s: got %d candles\n", symbol, len(candles))
+		return
+	}
+	completedCandle := candles[len(candles)-1]
+
+	series := futures.NewSeries(candles)
+	indicators, err := calculateIndicators(series)
+	if err != nil {
+		log.Printf("❌ Error calculating indicators for %s: %v\n", symbol, err)
+		return
+	}
+
+	// 미결제약정, 롱/숏 비율은 참고용이므로 조회에 실패해도 평가는 계속
+	stats, err := client.GetMarketStatsContext(ctx, symbol, marketStatsPeriod)
+	if err != nil {
+		log.Printf("⚠️ Error fetching market stats for %s: %v\n", symbol, err)
+	}
+
+	signalType, conditions, stopLoss, takeProfit := generateSignal(series, indicators, stats)
+
+	if signalType != tracker.LastSignal || completedCandle.CloseTime != tracker.LastSignalTime {
+		signalResult := lib.SignalResult{
+			Symbol:     symbol,
+			Signal:     signalType,
+			Timestamp:  completedCandle.CloseTime,
+			Price:      completedCandle.Close,
+			Conditions: conditions,
+			StopLoss:   stopLoss,
+			TakeProfit: takeProfit,
+		}
+		// 시그널 처리 중 에러가 발생해도 다음 심볼 처리를 위해 로그만 남김
+		if err := processSignal(ctx, signalResult, stats); err != nil {
+			log.Printf("Error processing signal for %s: %v", symbol, err)
+		}
+
+		tracker.LastSignal = signalType
+		tracker.LastSignalTime = completedCandle.CloseTime
+	}
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "download" {
+		if err := runDownload(os.Args[2:]); err != nil {
+			log.Fatalf("❌ Download failed: %v", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(os.Args[2:]); err != nil {
+			log.Fatalf("❌ Import failed: %v", err)
+		}
+		return
+	}
+
+	loadConfig()
 
 	log.Println("Starting BTC Signal Generator with Notifications...")
+	log.Printf("🌐 Binance environment: %s (REST: %s, WS: %s)", binanceEnv.Label(), binanceEnv.RESTBaseURL, binanceEnv.WSBaseURL)
+	if !binanceEnv.Testnet {
+		log.Printf("⚠️ LIVE trading environment - orders use real funds")
+	}
 
 	runningMutex.Lock()
 	isRunning = true
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/assist-by/mono-buy/futures"
+)
+
+func TestEvaluationTime(t *testing.T) {
+	candles := []futures.CandleData{
+		{OpenTime: 0, CloseTime: 59999},
+		{OpenTime: 60000, CloseTime: 119999},
+	}
+	streamClosed := candles[1]
+
+	tests := []struct {
+		name         string
+		now          int64
+		closedCandle *futures.CandleData
+		// 평가에 쓰일 마지막 캔들의 OpenTime
+		wantLast int64
+	}{
+		{"REST at CloseTime keeps forming candle out", 119999, nil, 0},
+		{"REST at CloseTime+1 includes candle", 120000, nil, 60000},
+		{"REST with forming last candle", 90000, nil, 0},
+		// 스트림의 x:true 이벤트는 서버가 마감을 확인한 것이므로 서버 시간 추정이 늦어도 포함
+		{"stream event while server time lags CloseTime", 119990, &streamClosed, 60000},
+		{"stream event at CloseTime", 119999, &streamClosed, 60000},
+		{"stream event after close", 125000, &streamClosed, 60000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := evaluationTime(tt.now, tt.closedCandle)
+			closed := futures.ClosedCandles(candles, now)
+			if len(closed) == 0 {
+				t.Fatalf("no closed candles at %d", now)
+			}
+			if got := closed[len(closed)-1].OpenTime; got != tt.wantLast {
+				t.Errorf("last evaluated candle OpenTime = %d, want %d (evaluation time %d)", got, tt.wantLast, now)
+			}
+		})
+	}
+}
+
+func TestEvaluationTimeDoesNotMoveBackwards(t *testing.T) {
+	closedCandle := futures.CandleData{OpenTime: 60000, CloseTime: 119999}
+	if got := evaluationTime(130000, &closedCandle); got != 130000 {
+		t.Errorf("evaluationTime = %d, want server time 130000", got)
+	}
+}
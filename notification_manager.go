@@ -6,6 +6,7 @@ import (
 
 	lib "github.com/assist-by/libStruct"
 	"github.com/assist-by/mono-buy/discord"
+	"github.com/assist-by/mono-buy/futures"
 )
 
 // processSignal과 generateDiscordEmbed 함수
@@ -87,7 +88,7 @@ import (
 // 	}
 // }
 
-func generateDiscordEmbed(signalResult lib.SignalResult) *discord.Embed {
+func generateDiscordEmbed(signalResult lib.SignalResult, stats *futures.MarketStats) *discord.Embed {
 	koreaLocation, _ := time.LoadLocation("Asia/Seoul")
 	timestamp := time.Unix(signalResult.Timestamp/1000, 0).In(koreaLocation)
 
@@ -164,6 +165,22 @@ func generateDiscordEmbed(signalResult lib.SignalResult) *discord.Embed {
 			signalResult.Conditions.Short.ParabolicSARDiff),
 		true)
 
+	// 포지션 통계 필드 추가
+	if stats != nil {
+		embed.AddField(fmt.Sprintf("📊 POSITIONING (%s)", stats.Period),
+			fmt.Sprintf("```\n[OI]: %.3f (%+.2f%%)\n[OI Value]: $%.0f\n[Top Trader L/S]: %.4f (L %.2f%% / S %.2f%%)\n[Global L/S]: %.4f (L %.2f%% / S %.2f%%)```",
+				stats.OpenInterest,
+				stats.OpenInterestChange,
+				stats.OpenInterestValue,
+				stats.TopTraderRatio.LongShortRatio,
+				stats.TopTraderRatio.LongAccount*100,
+				stats.TopTraderRatio.ShortAccount*100,
+				stats.GlobalRatio.LongShortRatio,
+				stats.GlobalRatio.LongAccount*100,
+				stats.GlobalRatio.ShortAccount*100),
+			false)
+	}
+
 	return embed
 }
 
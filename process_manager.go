@@ -1,19 +1,31 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 
 	lib "github.com/assist-by/libStruct"
-	"github.com/assist-by/mono-buy/discord"
 	"github.com/assist-by/mono-buy/futures"
+	"github.com/shopspring/decimal"
 )
 
-func processSignal(signalResult lib.SignalResult) error {
+// 시장가 진입 전 호가 점검
+const orderBookDepthLimit = 100
+
+var (
+	// 스프레드가 이 값(%)보다 크면 진입하지 않음
+	maxSpreadPercent = decimal.RequireFromString("0.1")
+	// 중간 가격에서 이 범위(%) 안의 반대편 호가 잔량이 주문 수량보다 적으면 진입하지 않음
+	liquidityRangePercent = decimal.RequireFromString("0.5")
+)
+
+func processSignal(ctx context.Context, signalResult lib.SignalResult, stats *futures.MarketStats) error {
 	log.Printf("Processing signal for %s...", signalResult.Symbol)
 
 	// 주문 전송
-	if err := sendNotification(signalResult); err != nil {
+	if err := sendNotification(signalResult, stats); err != nil {
 		log.Printf("❌ Error sending notification for %s: %v", signalResult.Symbol, err)
 		return fmt.Errorf("sending notification for %s: %w", signalResult.Symbol, err)
 	}
@@ -25,7 +37,7 @@ func processSignal(signalResult lib.SignalResult) error {
 	// }
 
 	// 주문 전송
-	if err := sendOrder(signalResult); err != nil {
+	if err := sendOrder(ctx, signalResult); err != nil {
 		log.Printf("❌ Error sending order for %s: %v", signalResult.Symbol, err)
 		return fmt.Errorf("sending order for %s: %w", signalResult.Symbol, err)
 	}
@@ -35,12 +47,12 @@ func processSignal(signalResult lib.SignalResult) error {
 }
 
 // send notification
-func sendNotification(signalResult lib.SignalResult) error {
-	discordClient := discord.NewClient(discordWebhookURL)
+func sendNotification(signalResult lib.SignalResult, stats *futures.MarketStats) error {
+	discordClient := newDiscordClient(discordWebhookURL)
 	log.Printf("Processing signal: %+v", signalResult)
 
 	// discord embedding
-	discordEmbed := generateDiscordEmbed(signalResult)
+	discordEmbed := generateDiscordEmbed(signalResult, stats)
 
 	// discord로 알림 보내기
 	if err := discordClient.Send(discordEmbed); err != nil {
@@ -52,38 +64,42 @@ func sendNotification(signalResult lib.SignalResult) error {
 
 	return nil
 }
-func sendOrder(signalResult lib.SignalResult) error {
+func sendOrder(ctx context.Context, signalResult lib.SignalResult) error {
 	log.Printf("Starting sendOrder for %s", signalResult.Symbol)
 
 	// send buy api
-	client := futures.NewClient(apikey, secretkey)
-	discordClient := discord.NewClient(discordWebhookTradeURL)
+	client := newFuturesClient()
+	discordClient := newDiscordClient(discordWebhookTradeURL)
 
 	// 1. Hedge 모드 설정
 	log.Printf("Setting hedge mode for %s", signalResult.Symbol)
-	if err := client.SetPositionMode(true); err != nil {
+	if err := client.SetPositionModeContext(ctx, true); err != nil {
 		log.Printf("❌ Hedge mode error for %s: %v", signalResult.Symbol, err)
 		return fmt.Errorf("setting hedge mode: %w", err)
 	}
 
 	// 2. 심볼 정보 조회
 	log.Printf("Getting symbol info for %s", signalResult.Symbol)
-	symbolInfo, err := client.GetSymbolInfo(signalResult.Symbol)
+	symbolInfo, err := client.GetSymbolInfoContext(ctx, signalResult.Symbol)
 	if err != nil {
 		log.Printf("❌ Symbol info error for %s: %v", signalResult.Symbol, err)
 		return fmt.Errorf("getting symbol info: %w", err)
 	}
+	if !symbolInfo.IsTrading() {
+		log.Printf("❗ %s is not trading (status: %s), skipping", signalResult.Symbol, symbolInfo.Status)
+		return fmt.Errorf("symbol %s is not trading: %s", signalResult.Symbol, symbolInfo.Status)
+	}
 
 	// 3. 레버리지 설정
 	log.Printf("Setting leverage for %s", signalResult.Symbol)
-	if err := client.SetLeverage(signalResult.Symbol, 20); err != nil {
+	if err := client.SetLeverageContext(ctx, signalResult.Symbol, 20); err != nil {
 		log.Printf("❌ Leverage error for %s: %v", signalResult.Symbol, err)
 		return fmt.Errorf("setting leverage: %w", err)
 	}
 
 	// USDT 잔고 조회
 	log.Printf("Getting wallet balance for %s", signalResult.Symbol)
-	balances, err := client.GetWalletBalance()
+	balances, err := client.GetWalletBalanceContext(ctx)
 	if err != nil {
 		log.Printf("❌ Balance error for %s: %v", signalResult.Symbol, err)
 		return fmt.Errorf("getting wallet balance: %w", err)
@@ -91,24 +107,46 @@ func sendOrder(signalResult lib.SignalResult) error {
 
 	log.Printf("=== Processing %s ===", signalResult.Symbol)
 	usdtBalance := balances["USDT"]
-	positionSize := usdtBalance.Free / signalResult.Price
-	positionSize = futures.FloorToStepSize(positionSize, symbolInfo.StepSize)
+	price := decimal.NewFromFloat(signalResult.Price)
+	positionSize := usdtBalance.Free.Div(price)
+
+	// 시장가 주문은 MARKET_LOT_SIZE 기준 (없으면 LOT_SIZE)
+	lotSize := symbolInfo.MarketLotSize
+	if !lotSize.StepSize.IsPositive() {
+		lotSize = symbolInfo.LotSize
+	}
+	if lotSize.MaxQty.IsPositive() && positionSize.GreaterThan(lotSize.MaxQty) {
+		log.Printf("Position size %s exceeds max market qty %s for %s", positionSize, lotSize.MaxQty, signalResult.Symbol)
+		positionSize = lotSize.MaxQty
+	}
 
 	// 정밀도 로깅 추가
-	log.Printf("Raw Position Size before floor: %.8f", positionSize)
-	log.Printf("Step Size for %s: %.8f", signalResult.Symbol, symbolInfo.StepSize)
-	log.Printf("USDT Balance: %.2f for %s", usdtBalance.Free, signalResult.Symbol)
-	log.Printf("Position Size: %.8f for %s", positionSize, signalResult.Symbol)
-	log.Printf("Notional Value: %.2f for %s", positionSize*signalResult.Price, signalResult.Symbol)
-	log.Printf("Min Notional: %.2f for %s", symbolInfo.MinNotional, signalResult.Symbol)
-
-	// 최소 주문 금액 체크
-	if positionSize*signalResult.Price < symbolInfo.MinNotional {
+	log.Printf("Raw Position Size before floor: %s", positionSize)
+	positionSize = futures.FloorToStep(positionSize, lotSize.StepSize)
+	notional := positionSize.Mul(price)
+	log.Printf("Step Size for %s: %s", signalResult.Symbol, lotSize.StepSize)
+	log.Printf("USDT Balance: %s for %s", usdtBalance.Free.StringFixed(2), signalResult.Symbol)
+	log.Printf("Position Size: %s for %s", positionSize, signalResult.Symbol)
+	log.Printf("Notional Value: %s for %s", notional.StringFixed(2), signalResult.Symbol)
+	log.Printf("Min Notional: %s for %s", symbolInfo.MinNotional, signalResult.Symbol)
+
+	// 최소 주문 수량, 금액 체크
+	if positionSize.LessThan(lotSize.MinQty) {
+		log.Printf("❗ Order quantity too small for %s", signalResult.Symbol)
+		err := fmt.Errorf("order quantity too small. minimum quantity: %v", lotSize.MinQty)
+		if discordClient != nil {
+			if notifyErr := discordClient.SendTradeNotification(signalResult, positionSize.InexactFloat64(), err); notifyErr != nil {
+				log.Printf("❌ Failed to send Discord notification for %s: %v", signalResult.Symbol, notifyErr)
+			}
+		}
+		return err
+	}
+	if notional.LessThan(symbolInfo.MinNotional) {
 		log.Printf("❗ Order size too small for %s", signalResult.Symbol)
 		err := fmt.Errorf("order size too small. minimum notional: %v", symbolInfo.MinNotional)
 		if discordClient != nil {
 			log.Printf("Sending notification for small order size for %s", signalResult.Symbol)
-			if notifyErr := discordClient.SendTradeNotification(signalResult, positionSize, err); notifyErr != nil {
+			if notifyErr := discordClient.SendTradeNotification(signalResult, positionSize.InexactFloat64(), err); notifyErr != nil {
 				log.Printf("❌ Failed to send Discord notification for %s: %v", signalResult.Symbol, notifyErr)
 			}
 		}
@@ -117,6 +155,11 @@ func sendOrder(signalResult lib.SignalResult) error {
 
 	log.Printf("Passed minimum order check for %s", signalResult.Symbol)
 
+	// TP/SL은 tickSize 단위로 맞춰야 정밀도 오류로 거절되지 않음
+	tickSize := symbolInfo.PriceFilter.TickSize
+	stopLoss := futures.RoundToTick(decimal.NewFromFloat(signalResult.StopLoss), tickSize)
+	takeProfit := futures.RoundToTick(decimal.NewFromFloat(signalResult.TakeProfit), tickSize)
+
 	// 주문 생성
 	var order futures.OrderRequest
 	switch signalResult.Signal {
@@ -127,11 +170,11 @@ func sendOrder(signalResult lib.SignalResult) error {
 			PositionSide: futures.LONG,
 			Type:         "MARKET",
 			Quantity:     positionSize,
-			StopLoss:     signalResult.StopLoss,
-			TakeProfit:   signalResult.TakeProfit,
+			StopLoss:     stopLoss,
+			TakeProfit:   takeProfit,
 		}
-		log.Printf("🚀 Opening LONG position for %s at %.2f (TP: %.2f, SL: %.2f)",
-			signalResult.Symbol, signalResult.Price, signalResult.TakeProfit, signalResult.StopLoss)
+		log.Printf("🚀 Opening LONG position for %s at %.2f (TP: %s, SL: %s)",
+			signalResult.Symbol, signalResult.Price, takeProfit, stopLoss)
 
 	case lib.SIGNAL_SHORT:
 		order = futures.OrderRequest{
@@ -140,31 +183,91 @@ func sendOrder(signalResult lib.SignalResult) error {
 			PositionSide: futures.SHORT,
 			Type:         "MARKET",
 			Quantity:     positionSize,
-			StopLoss:     signalResult.StopLoss,
-			TakeProfit:   signalResult.TakeProfit,
+			StopLoss:     stopLoss,
+			TakeProfit:   takeProfit,
 		}
-		log.Printf("🔻 Opening SHORT position for %s at %.2f (TP: %.2f, SL: %.2f)",
-			signalResult.Symbol, signalResult.Price, signalResult.TakeProfit, signalResult.StopLoss)
+		log.Printf("🔻 Opening SHORT position for %s at %.2f (TP: %s, SL: %s)",
+			signalResult.Symbol, signalResult.Price, takeProfit, stopLoss)
 
 	case lib.SIGNAL_NO_SIGANL:
 		return nil
 	}
 
+	// 같은 시그널로는 한 번만 주문되도록 clientOrderId 고정
+	order.ClientOrderID = futures.NewClientOrderID(signalResult.Symbol, signalResult.Signal.String(), signalResult.Timestamp)
+
+	// 같은 방향 포지션이 이미 열려 있으면 추가 진입하지 않음
+	position, err := client.GetPositionContext(ctx, order.Symbol, order.PositionSide)
+	if err != nil {
+		log.Printf("❌ Position error for %s: %v", signalResult.Symbol, err)
+		return fmt.Errorf("getting position: %w", err)
+	}
+	if position.IsOpen() {
+		log.Printf("⏭️ %s position already open for %s (amt: %s, entry: %s), skipping",
+			order.PositionSide, signalResult.Symbol, position.PositionAmt, position.EntryPrice)
+		return nil
+	}
+
+	// TP/SL은 마크 가격 기준으로 발동되므로 이미 넘어선 가격이면 즉시 발동되어 주문하지 않음
+	premiumIndex, err := client.GetPremiumIndexContext(ctx, order.Symbol)
+	if err != nil {
+		log.Printf("❌ Mark price error for %s: %v", signalResult.Symbol, err)
+		return fmt.Errorf("getting mark price: %w", err)
+	}
+	markPrice := premiumIndex.MarkPrice
+	log.Printf("Mark price for %s: %s (index: %s, funding: %s%%)",
+		signalResult.Symbol, markPrice, premiumIndex.IndexPrice, premiumIndex.LastFundingRate.Mul(decimal.NewFromInt(100)).StringFixed(6))
+
+	if (order.PositionSide == futures.LONG && ((order.StopLoss.IsPositive() && order.StopLoss.GreaterThanOrEqual(markPrice)) || (order.TakeProfit.IsPositive() && order.TakeProfit.LessThanOrEqual(markPrice)))) ||
+		(order.PositionSide == futures.SHORT && ((order.StopLoss.IsPositive() && order.StopLoss.LessThanOrEqual(markPrice)) || (order.TakeProfit.IsPositive() && order.TakeProfit.GreaterThanOrEqual(markPrice)))) {
+		err := fmt.Errorf("TP/SL already crossed by mark price %s (TP: %s, SL: %s)", markPrice, order.TakeProfit, order.StopLoss)
+		log.Printf("❗ %v for %s", err, signalResult.Symbol)
+		if discordClient != nil {
+			discordClient.SendTradeNotification(signalResult, positionSize.InexactFloat64(), err)
+		}
+		return err
+	}
+
+	// 스프레드가 넓거나 호가가 얇으면 시장가 주문이 크게 밀리므로 진입하지 않음
+	depth, err := client.GetDepthContext(ctx, order.Symbol, orderBookDepthLimit)
+	if err != nil {
+		log.Printf("❌ Depth error for %s: %v", signalResult.Symbol, err)
+		return fmt.Errorf("getting depth: %w", err)
+	}
+	if err := checkLiquidity(depth, order.Side, positionSize); err != nil {
+		log.Printf("❗ %v for %s", err, signalResult.Symbol)
+		if discordClient != nil {
+			discordClient.SendTradeNotification(signalResult, positionSize.InexactFloat64(), err)
+		}
+		return err
+	}
+
 	// 주문 실행
+	var orderResp *futures.OrderResponse
 	if err := func() error {
 		defer func() {
 			if r := recover(); r != nil {
 				log.Printf("Panic in placing order: %v", r)
 				if discordClient != nil {
-					discordClient.SendTradeNotification(signalResult, positionSize, fmt.Errorf("order placement panic: %v", r))
+					discordClient.SendTradeNotification(signalResult, positionSize.InexactFloat64(), fmt.Errorf("order placement panic: %v", r))
 				}
 			}
 		}()
 
-		if err := client.PlaceOrder(order); err != nil {
-			log.Printf("Error placing order: %v", err)
+		// 진입 + TP/SL을 함께 걸고, 보호 주문 실패 시 포지션 정리
+		bracket, err := client.PlaceBracketOrderContext(ctx, order)
+		if bracket != nil {
+			resp := bracket.Entry
+			orderResp = resp
+			log.Printf("Order %d for %s: status=%s executedQty=%s avgPrice=%s",
+				resp.OrderID, resp.Symbol, resp.Status, resp.ExecutedQty, resp.AvgPrice)
+			log.Printf("Bracket for %s: protected=%v rolledBack=%v",
+				resp.Symbol, bracket.Protected, bracket.RolledBack)
+		}
+		if err != nil {
+			log.Printf("Error placing order (%s): %v", describeOrderError(err), err)
 			if discordClient != nil {
-				discordClient.SendTradeNotification(signalResult, positionSize, err)
+				discordClient.SendTradeNotification(signalResult, positionSize.InexactFloat64(), fmt.Errorf("%s: %w", describeOrderError(err), err))
 			}
 			return fmt.Errorf("placing order: %w", err)
 		}
@@ -173,6 +276,19 @@ func sendOrder(signalResult lib.SignalResult) error {
 		return err
 	}
 
+	// panic 등으로 응답을 받지 못했다면 체결 완료로 알리지 않음
+	if orderResp == nil {
+		return fmt.Errorf("placing order: no order response for %s", signalResult.Symbol)
+	}
+
+	// 실제 체결된 수량, 가격으로 알림
+	if orderResp.ExecutedQty.IsPositive() {
+		positionSize = orderResp.ExecutedQty
+		if orderResp.AvgPrice.IsPositive() {
+			signalResult.Price = orderResp.AvgPrice.InexactFloat64()
+		}
+	}
+
 	// 성공 알림 전송
 	if discordClient != nil {
 		log.Printf("Sending success notification")
@@ -182,7 +298,7 @@ func sendOrder(signalResult lib.SignalResult) error {
 					log.Printf("Panic in sending success notification: %v", r)
 				}
 			}()
-			discordClient.SendTradeNotification(signalResult, positionSize, nil)
+			discordClient.SendTradeNotification(signalResult, positionSize.InexactFloat64(), nil)
 			return nil
 		}(); err != nil {
 			log.Printf("Error sending success notification: %v", err)
@@ -191,3 +307,49 @@ func sendOrder(signalResult lib.SignalResult) error {
 
 	return nil
 }
+
+// 스프레드와 진입 방향의 호가 잔량 확인 (BUY는 매도호가, SELL은 매수호가를 소진)
+func checkLiquidity(depth *futures.Depth, side futures.OrderSide, quantity decimal.Decimal) error {
+	_, spreadPercent, ok := depth.Spread()
+	if !ok {
+		return fmt.Errorf("empty order book")
+	}
+	if spreadPercent.GreaterThan(maxSpreadPercent) {
+		return fmt.Errorf("spread too wide: %s%% (max %s%%)", spreadPercent.StringFixed(4), maxSpreadPercent)
+	}
+
+	summary := depth.DepthWithin(liquidityRangePercent)
+	available := summary.AskQuantity
+	if side == futures.SELL {
+		available = summary.BidQuantity
+	}
+	if available.LessThan(quantity) {
+		return fmt.Errorf("insufficient liquidity within %s%%: %s available, %s required", liquidityRangePercent, available, quantity)
+	}
+
+	log.Printf("Order book for %s: spread %s%%, liquidity within %s%%: %s", depth.Symbol, spreadPercent.StringFixed(4), liquidityRangePercent, available)
+	return nil
+}
+
+// 주문 실패 원인별 알림 문구
+func describeOrderError(err error) string {
+	switch {
+	case errors.Is(err, futures.ErrIPBanned):
+		return "IP 차단됨 (차단 해제 전까지 요청 중단)"
+	case errors.Is(err, futures.ErrRateLimited):
+		return "요청 한도 초과"
+	case errors.Is(err, futures.ErrInsufficientMargin):
+		return "증거금 부족"
+	case errors.Is(err, futures.ErrInvalidQuantity):
+		return "수량/가격 정밀도 오류"
+	case errors.Is(err, futures.ErrTimestamp):
+		return "서버 시간 불일치"
+	case errors.Is(err, futures.ErrReduceOnlyRejected):
+		return "reduce only 주문 거절"
+	case errors.Is(err, futures.ErrUnknownOrder):
+		return "존재하지 않는 주문"
+	case errors.Is(err, futures.ErrUnknownResult):
+		return "주문 결과 확인 불가 (포지션 직접 확인 필요)"
+	}
+	return "주문 실패"
+}
@@ -2,48 +2,26 @@ package main
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/assist-by/abmodule/calculate"
 	lib "github.com/assist-by/libStruct"
 	future "github.com/assist-by/mono-buy/futures"
 )
 
-// func processCandles(candles []future.CandleData, now time.Time) (lastCompleteCandle future.CandleData, err error) {
-// 	if len(candles) < 2 {
-// 		return future.CandleData{}, fmt.Errorf("insufficient candles data")
-// 	}
-
-// 	// 마지막 캔들의 종료 시간을 확인
-// 	lastCandle := candles[len(candles)-1]
-// 	lastCandleClose := time.Unix(lastCandle.CloseTime/1000, 0)
-
-// 	// 현재 시간이 마지막 캔들의 종료 시간보다 크거나 같으면
-// 	// 마지막 캔들이 완료된 캔들임
-// 	if now.Equal(lastCandleClose) || now.After(lastCandleClose) {
-// 		return lastCandle, nil
-// 	}
-
-// 	// 그렇지 않으면 마지막에서 두 번째 캔들이 완료된 마지막 캔들
-// 	return candles[len(candles)-2], nil
-// }
-
 // 매수 신호 생성 함수
-func generateSignal(candles []future.CandleData, indicators lib.TechnicalIndicators) (lib.SignalType, lib.SignalConditions, float64, float64) {
-	if len(candles) < 2 { // 최소 2개의 캔들 필요
+// stats는 미결제약정, 롱/숏 비율 (조회 실패 시 nil), 포지셔닝 조건을 쓰는 전략에서 참고
+func generateSignal(series *future.Series, indicators lib.TechnicalIndicators, stats *future.MarketStats) (lib.SignalType, lib.SignalConditions, float64, float64) {
+	if series.Len() < 2 { // 최소 2개의 캔들 필요
 		// 캔들조회 에러
 		return lib.SIGNAL_NO_SIGANL, lib.SignalConditions{}, 0.0, 0.0
 	}
 
-	lastPrice, _ := strconv.ParseFloat(candles[len(candles)-1].Close, 64)
-	lastHigh, _ := strconv.ParseFloat(candles[len(candles)-1].High, 64)
-	lastLow, _ := strconv.ParseFloat(candles[len(candles)-1].Low, 64)
+	last := series.Len() - 1
+	lastPrice := series.Close[last]
+	lastHigh := series.High[last]
+	lastLow := series.Low[last]
 
-	prevPrices := make([]float64, len(candles)-1)
-	for i := 0; i < len(candles)-1; i++ {
-		price, _ := strconv.ParseFloat(candles[i].Close, 64)
-		prevPrices[i] = price
-	}
+	prevPrices := series.Close[:last]
 
 	prevMACDLine, prevSignalLine := calculate.CalculateMACD(prevPrices)
 
@@ -111,38 +89,14 @@ func generateSignal(candles []future.CandleData, indicators lib.TechnicalIndicat
 }
 
 // 보조지표값 계산 함수
-func calculateIndicators(candles []future.CandleData) (lib.TechnicalIndicators, error) {
-	if len(candles) < 300 {
-		return lib.TechnicalIndicators{}, fmt.Errorf("insufficient data: need at least 300 candles, got %d", len(candles))
-	}
-
-	prices := make([]float64, len(candles))
-	highs := make([]float64, len(candles))
-	lows := make([]float64, len(candles))
-
-	for i, candle := range candles {
-		price, err := strconv.ParseFloat(candle.Close, 64)
-		if err != nil {
-			return lib.TechnicalIndicators{}, fmt.Errorf("error parsing close price: %v", err)
-		}
-		prices[i] = price
-
-		high, err := strconv.ParseFloat(candle.High, 64)
-		if err != nil {
-			return lib.TechnicalIndicators{}, fmt.Errorf("error parsing high price: %v", err)
-		}
-		highs[i] = high
-
-		low, err := strconv.ParseFloat(candle.Low, 64)
-		if err != nil {
-			return lib.TechnicalIndicators{}, fmt.Errorf("error parsing low price: %v", err)
-		}
-		lows[i] = low
+func calculateIndicators(series *future.Series) (lib.TechnicalIndicators, error) {
+	if series.Len() < 300 {
+		return lib.TechnicalIndicators{}, fmt.Errorf("insufficient data: need at least 300 candles, got %d", series.Len())
 	}
 
-	ema200 := calculate.CalculateEMA(prices, 200)
-	macdLine, signalLine := calculate.CalculateMACD(prices)
-	parabolicSAR := calculate.CalculateParabolicSAR(highs, lows)
+	ema200 := calculate.CalculateEMA(series.Close, 200)
+	macdLine, signalLine := calculate.CalculateMACD(series.Close)
+	parabolicSAR := calculate.CalculateParabolicSAR(series.High, series.Low)
 
 	return lib.TechnicalIndicators{
 		EMA200:       ema200,
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/assist-by/mono-buy/discord"
+	"github.com/assist-by/mono-buy/futures"
+	"github.com/shopspring/decimal"
+)
+
+// 사용자 데이터 스트림 시작 (체결, 잔고/포지션 변경, 마진콜)
+func startUserDataStream(ctx context.Context, client *futures.FutureClient) <-chan futures.UserDataEvent {
+	events := make(chan futures.UserDataEvent, 64)
+	userStream := client.NewUserDataStream()
+
+	go func() {
+		for {
+			err := userStream.Run(ctx, events)
+			if ctx.Err() != nil {
+				return
+			}
+			// listenKey 발급 실패 등으로 종료되면 잠시 후 다시 시작
+			log.Printf("❌ User data stream stopped: %v\n", err)
+			select {
+			case <-time.After(time.Minute):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// 사용자 데이터 이벤트 처리
+// 잔고는 walletBalances에 반영하고, TP/SL 체결, 청산, 마진콜은 알림 전송
+func handleUserDataEvent(event futures.UserDataEvent, walletBalances map[string]decimal.Decimal) {
+	switch event.Type {
+	case futures.EVENT_ORDER_TRADE_UPDATE:
+		order := event.OrderUpdate
+		log.Printf("📝 Order update %s %s %s %s: status=%s filled=%s/%s avg=%s",
+			order.Symbol, order.PositionSide, order.Side, order.OrigType, order.Status,
+			order.FilledQty, order.OrigQty, order.AvgPrice)
+
+		if order.Status != "FILLED" {
+			return
+		}
+
+		var title string
+		switch order.OrigType {
+		case "TAKE_PROFIT_MARKET":
+			title = "🎯 목표가 도달"
+		case "STOP_MARKET":
+			title = "🛑 손절"
+		case "LIQUIDATION":
+			title = "💀 강제 청산"
+		default:
+			return
+		}
+
+		description := fmt.Sprintf("**심볼**: %s\n**포지션**: %s\n**체결수량**: %s\n**체결가**: $%s\n**실현손익**: %s USDT",
+			order.Symbol, order.PositionSide, order.FilledQty, order.AvgPrice, order.RealizedProfit.StringFixed(4))
+		color := discord.ColorGreen
+		if order.RealizedProfit.IsNegative() {
+			color = discord.ColorRed
+		}
+		sendAccountAlert(title, description, color)
+
+	case futures.EVENT_ACCOUNT_UPDATE:
+		for _, balance := range event.AccountUpdate.Balances {
+			walletBalances[balance.Asset] = balance.WalletBalance
+			log.Printf("🏦 Balance update (%s) %s: %s (변동: %s)",
+				event.AccountUpdate.Reason, balance.Asset, balance.WalletBalance, balance.BalanceChange)
+		}
+		for _, position := range event.AccountUpdate.Positions {
+			log.Printf("📊 Position update %s %s: amt=%s entry=%s upnl=%s",
+				position.Symbol, position.PositionSide, position.PositionAmt, position.EntryPrice, position.UnRealizedProfit)
+		}
+
+	case futures.EVENT_MARGIN_CALL:
+		description := fmt.Sprintf("**교차 지갑 잔고**: %s USDT\n", event.MarginCall.CrossWalletBalance.StringFixed(4))
+		for _, position := range event.MarginCall.Positions {
+			description += fmt.Sprintf("**%s %s**: 수량 %s, 마크가 $%s, 미실현손익 %s, 유지증거금 %s\n",
+				position.Symbol, position.PositionSide, position.PositionAmt,
+				position.MarkPrice, position.UnRealizedProfit.StringFixed(4), position.MaintenanceMargin.StringFixed(4))
+		}
+		log.Printf("⚠️ Margin call: %s", description)
+		sendAccountAlert("⚠️ 마진콜", description, discord.ColorRed)
+	}
+}
+
+func sendAccountAlert(title, description string, color int) {
+	discordClient := newDiscordClient(discordWebhookTradeURL)
+	if err := discordClient.SendAccountAlert(title, description, color); err != nil {
+		log.Printf("❌ Failed to send account alert: %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/assist-by/mono-buy/discord"
+	"github.com/assist-by/mono-buy/futures"
+)
+
+// 꼬리 길이가 캔들 범위 중앙값의 이 배수보다 길면 이상치 (CANDLE_WICK_FACTOR)
+const defaultCandleWickFactor = 10.0
+
+var (
+	// 문제 유형별 처리 방법 (CANDLE_<TYPE>_ACTION, 예: CANDLE_GAP_ACTION=skip)
+	candleIssueActions = map[futures.CandleIssueType]futures.CandleAction{
+		futures.CANDLE_ISSUE_OUT_OF_ORDER: futures.CANDLE_ACTION_REPAIR,
+		futures.CANDLE_ISSUE_DUPLICATE:    futures.CANDLE_ACTION_REPAIR,
+		futures.CANDLE_ISSUE_GAP:          futures.CANDLE_ACTION_REPAIR,
+		futures.CANDLE_ISSUE_ZERO_VOLUME:  futures.CANDLE_ACTION_ALERT,
+		futures.CANDLE_ISSUE_WICK:         futures.CANDLE_ACTION_ALERT,
+	}
+	candleWickFactor = defaultCandleWickFactor
+)
+
+// 캔들 점검 설정 로드
+func loadCandleCheckConfig() error {
+	for _, issueType := range futures.CandleIssueTypes {
+		key := "CANDLE_" + strings.ToUpper(string(issueType)) + "_ACTION"
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		action := futures.CandleAction(strings.ToLower(value))
+		if !action.Valid() {
+			return fmt.Errorf("invalid %s: %s (repair, skip, alert)", key, value)
+		}
+		candleIssueActions[issueType] = action
+	}
+
+	if value := os.Getenv("CANDLE_WICK_FACTOR"); value != "" {
+		factor, err := strconv.ParseFloat(value, 64)
+		if err != nil || factor < 0 {
+			return fmt.Errorf("invalid CANDLE_WICK_FACTOR: %s", value)
+		}
+		candleWickFactor = factor
+	}
+	return nil
+}
+
+type candleAlertKey struct {
+	symbol    string
+	issueType futures.CandleIssueType
+}
+
+// 지표 계산 전 캔들 점검
+// 같은 문제가 매 주기 반복해서 알림되지 않도록 심볼, 유형별로 알림한 마지막 캔들 시각을 기억
+type candleChecker struct {
+	validator *futures.CandleValidator
+	alerted   map[candleAlertKey]int64
+}
+
+func newCandleChecker(interval string) (*candleChecker, error) {
+	validator, err := futures.NewCandleValidator(interval, candleWickFactor)
+	if err != nil {
+		return nil, err
+	}
+	return &candleChecker{
+		validator: validator,
+		alerted:   make(map[candleAlertKey]int64),
+	}, nil
+}
+
+// 설정에 따라 보정한 캔들 반환, ok가 false면 이번 주기에는 심볼을 건너뜀
+func (c *candleChecker) check(symbol string, candles []futures.CandleData) ([]futures.CandleData, bool) {
+	issues := c.validator.Validate(candles)
+	if len(issues) == 0 {
+		return candles, true
+	}
+
+	byType := make(map[futures.CandleIssueType][]futures.CandleIssue)
+	for _, issue := range issues {
+		byType[issue.Type] = append(byType[issue.Type], issue)
+	}
+
+	ok := true
+	for _, issueType := range futures.CandleIssueTypes {
+		found := byType[issueType]
+		if len(found) == 0 {
+			continue
+		}
+
+		switch candleIssueActions[issueType] {
+		case futures.CANDLE_ACTION_REPAIR:
+			log.Printf("🔧 %s: repairing %d %s issues (latest %s)", symbol, len(found), issueType, found[len(found)-1])
+			candles = c.validator.Repair(candles, issueType)
+		case futures.CANDLE_ACTION_SKIP:
+			log.Printf("⚠️ %s: %d %s issues (latest %s), skipping this tick", symbol, len(found), issueType, found[len(found)-1])
+			ok = false
+		case futures.CANDLE_ACTION_ALERT:
+			log.Printf("⚠️ %s: %d %s issues (latest %s)", symbol, len(found), issueType, found[len(found)-1])
+			c.alert(symbol, issueType, found)
+		}
+	}
+	return candles, ok
+}
+
+// 이전에 알림하지 않은 문제만 알림
+func (c *candleChecker) alert(symbol string, issueType futures.CandleIssueType, issues []futures.CandleIssue) {
+	key := candleAlertKey{symbol: symbol, issueType: issueType}
+	last := c.alerted[key]
+
+	var description strings.Builder
+	count := 0
+	for _, issue := range issues {
+		if issue.OpenTime <= last {
+			continue
+		}
+		if count < 10 {
+			fmt.Fprintf(&description, "- %s\n", issue)
+		}
+		count++
+		c.alerted[key] = max(c.alerted[key], issue.OpenTime)
+	}
+	if count == 0 {
+		return
+	}
+	if count > 10 {
+		fmt.Fprintf(&description, "... 외 %d건\n", count-10)
+	}
+
+	title := fmt.Sprintf("⚠️ 캔들 데이터 이상 (%s, %s)", symbol, issueType)
+	discordClient := newDiscordClient(discordWebhookURL)
+	if err := discordClient.SendAccountAlert(title, description.String(), discord.ColorRed); err != nil {
+		log.Printf("❌ Failed to send candle alert: %v", err)
+	}
+}